@@ -0,0 +1,167 @@
+package clitable
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Aggregate computes a footer value from the non-blank values of a column.
+type Aggregate func(values []interface{}) interface{}
+
+// count is the result of Count and CountDistinct. Column formats are not
+// applied to it.
+type count int
+
+var (
+	// Sum returns a time.Duration for durations, an int64 for integers and
+	// a float64 for other numbers.
+	Sum Aggregate = func(values []interface{}) interface{} {
+		total, n := sum(values)
+		if n == 0 {
+			return ""
+		}
+		return total
+	}
+	Avg Aggregate = func(values []interface{}) interface{} {
+		total, n := sum(values)
+		switch total := total.(type) {
+		case time.Duration:
+			return total / time.Duration(n)
+		case int64:
+			return float64(total) / float64(n)
+		case float64:
+			return total / float64(n)
+		}
+		return ""
+	}
+	Min Aggregate = func(values []interface{}) interface{} {
+		return extremum(values, func(a, b float64) bool { return a < b })
+	}
+	Max Aggregate = func(values []interface{}) interface{} {
+		return extremum(values, func(a, b float64) bool { return a > b })
+	}
+	Count Aggregate = func(values []interface{}) interface{} {
+		return count(len(values))
+	}
+	CountDistinct Aggregate = func(values []interface{}) interface{} {
+		distinct := make(map[string]bool)
+		for _, value := range values {
+			distinct[fmt.Sprintf("%v", value)] = true
+		}
+		return count(len(distinct))
+	}
+)
+
+// sum adds up the numbers in values and returns their count. The total is
+// a time.Duration if they are all durations, an int64 if they are all
+// integers and a float64 otherwise.
+func sum(values []interface{}) (interface{}, int) {
+	var total float64
+	var integer int64
+	n, durations, integers := 0, true, true
+	for _, value := range values {
+		f, ok := toFloat(value)
+		if !ok {
+			continue
+		}
+		n++
+		total += f
+		if _, ok := value.(time.Duration); !ok {
+			durations = false
+		}
+		if i, ok := toInt(value); ok {
+			integer += i
+		} else {
+			integers = false
+		}
+	}
+	switch {
+	case n == 0:
+		return nil, 0
+	case durations:
+		return time.Duration(integer), n
+	case integers:
+		return integer, n
+	}
+	return total, n
+}
+
+func toInt(value interface{}) (int64, bool) {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return 0, false
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return int64(v.Uint()), true
+	}
+	return 0, false
+}
+
+func toFloats(values []interface{}) []float64 {
+	numbers := make([]float64, 0, len(values))
+	for _, value := range values {
+		if n, ok := toFloat(value); ok {
+			numbers = append(numbers, n)
+		}
+	}
+	return numbers
+}
+
+func extremum(values []interface{}, better func(a, b float64) bool) interface{} {
+	var result interface{} = ""
+	var best float64
+	for _, value := range values {
+		if n, ok := toFloat(value); ok && (result == "" || better(n, best)) {
+			result, best = value, n
+		}
+	}
+	return result
+}
+
+// aggregateData prints the result of an aggregate in column i. The
+// column's Format applies to results of the column's type.
+func (t *Table) aggregateData(i int, result interface{}) string {
+	if isBlank(result) {
+		return ""
+	}
+	if format := t.columns[i].Format; format != nil && t.hasColumnType(i, result) {
+		return format(result)
+	}
+	return valueString(result)
+}
+
+// hasColumnType reports whether an aggregate result has the type of the
+// values in column i. Numbers count as the type of a numeric column;
+// counts never do.
+func (t *Table) hasColumnType(i int, result interface{}) bool {
+	if _, ok := result.(count); ok {
+		return false
+	}
+	_, isNumber := toFloat(result)
+	for _, value := range t.getColumnValues(i) {
+		if reflect.TypeOf(value) == reflect.TypeOf(result) {
+			return true
+		}
+		if _, ok := toFloat(value); ok && isNumber {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *Table) getColumnValues(i int) []interface{} {
+	values := make([]interface{}, 0, len(t.rows))
+	for _, row := range t.rows {
+		if !row.isHeader && !isBlank(row.cells[i].value) {
+			values = append(values, row.cells[i].value)
+		}
+	}
+	return values
+}
@@ -0,0 +1,152 @@
+package clitable
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestAggregates(t *testing.T) {
+	values := []interface{}{3, 1.5, "x", 3}
+	cases := map[string]struct {
+		aggregate Aggregate
+		expected  interface{}
+	}{
+		"sum":      {Sum, 7.5},
+		"avg":      {Avg, 2.5},
+		"min":      {Min, 1.5},
+		"max":      {Max, 3},
+		"count":    {Count, count(4)},
+		"distinct": {CountDistinct, count(3)},
+	}
+	for name, c := range cases {
+		if result := c.aggregate(values); result != c.expected {
+			t.Errorf("%s = %v, expected %v", name, result, c.expected)
+		}
+	}
+	if result := Sum([]interface{}{"x"}); result != "" {
+		t.Errorf("sum = %v", result)
+	}
+	if result := Sum([]interface{}{1200000, int32(34567)}); result != int64(1234567) {
+		t.Errorf("sum = %v", result)
+	}
+	durations := []interface{}{time.Second, 2 * time.Second}
+	if result := Sum(durations); result != 3*time.Second {
+		t.Errorf("sum = %v", result)
+	}
+	if result := Avg(durations); result != 1500*time.Millisecond {
+		t.Errorf("avg = %v", result)
+	}
+}
+
+func TestFooter(t *testing.T) {
+	table := NewTable("item", "price")
+	table.Style = &TableStyle{
+		VerticalBorder:   "|",
+		HorizontalBorder: "-",
+		Corner:           "+",
+		FooterBorder:     "=",
+		RowSeparators:    RowSeparatorsNone,
+	}
+	price := table.GetColumnByName("price")
+	price.Format = FormatDecimal(2)
+	price.FooterStyle = &ColumnStyle{Align: ColumnAlignRight}
+	table.AddFooter("Total", Sum)
+	table.AddFooter("Items", Count)
+	table.AddRow("tea", 2.5)
+	table.AddRow("cake", 10)
+	table.SortBy(Desc("price"))
+
+	header :=
+		"+-----+-----+\n" +
+			"|item |price|\n" +
+			"+-----+-----+\n" +
+			"|cake |10.00|\n" +
+			"|tea  |2.50 |\n" +
+			"+=====+=====+\n" +
+			"|Total|12.50|\n" +
+			"|Items|    2|\n" +
+			"+-----+-----+\n"
+
+	tableStr := table.String()
+	t.Log(tableStr)
+	t.Log(header)
+	if tableStr != header {
+		t.Fail()
+	}
+
+	buf := new(bytes.Buffer)
+	table.WriteCSV(buf, &CSVOptions{SkipHeader: true})
+	if buf.String() != "cake,10\ntea,2.5\n" {
+		t.Error(buf.String())
+	}
+
+	buf.Reset()
+	table.WriteCSV(buf, &CSVOptions{SkipHeader: true, IncludeFooters: true})
+	if buf.String() != "cake,10\ntea,2.5\nTotal,12.5\nItems,2\n" {
+		t.Error(buf.String())
+	}
+}
+
+func TestFooterTotals(t *testing.T) {
+	table := NewTable("item", "count", "price", "time")
+	table.Style = &TableStyle{
+		VerticalBorder:   "|",
+		HorizontalBorder: "-",
+		Corner:           "+",
+		FooterBorder:     "=",
+		RowSeparators:    RowSeparatorsNone,
+	}
+	table.GetColumnByName("price").Format = FormatDecimal(2)
+	table.AddFooter("Total", Sum, Sum, Sum)
+	table.AddRow("car", 1200000, 2500000.5, time.Second)
+	table.AddRow("bike", 34567, 1000000.25, time.Minute)
+
+	expected :=
+		"+-----+-------+----------+----+\n" +
+			"|item | count |  price   |time|\n" +
+			"+-----+-------+----------+----+\n" +
+			"|car  |1200000|2500000.50|1s  |\n" +
+			"|bike |34567  |1000000.25|1m0s|\n" +
+			"+=====+=======+==========+====+\n" +
+			"|Total|1234567|3500000.75|1m1s|\n" +
+			"+-----+-------+----------+----+\n"
+
+	tableStr := table.String()
+	t.Log(tableStr)
+	if tableStr != expected {
+		t.Fail()
+	}
+
+	buf := new(bytes.Buffer)
+	table.WriteCSV(buf, &CSVOptions{SkipHeader: true, IncludeFooters: true})
+	if buf.String() != "car,1200000,2500000.5,1s\nbike,34567,1000000.25,1m0s\nTotal,1234567,3500000.75,1m1s\n" {
+		t.Error(buf.String())
+	}
+}
+
+func TestFooterEmptyBody(t *testing.T) {
+	table := NewTable("item", "price")
+	table.Style = &TableStyle{
+		VerticalBorder:   "|",
+		HorizontalBorder: "-",
+		Corner:           "+",
+		FooterBorder:     "=",
+		RowSeparators:    RowSeparatorsNone,
+	}
+	table.GetColumnByName("price").Format = FormatDecimal(2)
+	table.AddFooter("Avg", Avg)
+
+	expected :=
+		"+----+-----+\n" +
+			"|item|price|\n" +
+			"+====+=====+\n" +
+			"|Avg |     |\n" +
+			"+----+-----+\n"
+
+	tableStr := table.String()
+	t.Log(tableStr)
+	if tableStr != expected {
+		t.Fail()
+	}
+}
@@ -0,0 +1,87 @@
+package clitable
+
+import "strings"
+
+const ansiReset = "\x1b[0m"
+
+// ansiSequenceLen returns the length in bytes of the escape sequence at the
+// start of s, or 0 if s does not start with one.
+func ansiSequenceLen(s string) int {
+	if len(s) < 2 || s[0] != '\x1b' {
+		return 0
+	}
+	switch s[1] {
+	case '[':
+		for i := 2; i < len(s); i++ {
+			if s[i] >= 0x40 && s[i] <= 0x7e {
+				return i + 1
+			}
+		}
+	case ']':
+		for i := 2; i < len(s); i++ {
+			if s[i] == '\a' {
+				return i + 1
+			}
+			if s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2
+			}
+		}
+	default:
+		return 2
+	}
+	return len(s)
+}
+
+func stripANSI(s string) string {
+	if !strings.Contains(s, "\x1b") {
+		return s
+	}
+	buf := new(strings.Builder)
+	for i := 0; i < len(s); i++ {
+		if n := ansiSequenceLen(s[i:]); n > 0 {
+			i += n - 1
+		} else {
+			buf.WriteByte(s[i])
+		}
+	}
+	return buf.String()
+}
+
+func isSGR(seq string) bool {
+	return len(seq) > 2 && seq[1] == '[' && seq[len(seq)-1] == 'm'
+}
+
+func isSGRReset(seq string) bool {
+	params := seq[2 : len(seq)-1]
+	return params == "" || params == "0" || strings.HasPrefix(params, "0;")
+}
+
+// closeStyles makes every line self-contained: SGR styles still active at
+// the end of a line are reset there and reopened at the start of the next.
+func closeStyles(lines []string) []string {
+	active := ""
+	for i, line := range lines {
+		prefix := active
+		for j := 0; j < len(line); j++ {
+			n := ansiSequenceLen(line[j:])
+			if n == 0 {
+				continue
+			}
+			seq := line[j : j+n]
+			if isSGR(seq) {
+				if isSGRReset(seq) {
+					active = ""
+				}
+				if seq != "\x1b[m" && seq != ansiReset {
+					active += seq
+				}
+			}
+			j += n - 1
+		}
+		if active != "" {
+			line += ansiReset
+		}
+		lines[i] = prefix + line
+	}
+	return lines
+}
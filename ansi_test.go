@@ -0,0 +1,46 @@
+package clitable
+
+import "testing"
+
+func TestANSIWidth(t *testing.T) {
+	if width := stringWidth("\x1b[31mred\x1b[0m"); width != 3 {
+		t.Errorf("width = %d, expected 3", width)
+	}
+	if width := stringWidth("\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\"); width != 4 {
+		t.Errorf("width = %d, expected 4", width)
+	}
+}
+
+func TestANSIWrap(t *testing.T) {
+	lines := closeStyles(wrap("\x1b[1;31mred bold text\x1b[0m plain", 11))
+	expected := []string{
+		"\x1b[1;31mred bold\x1b[0m",
+		"\x1b[1;31mtext\x1b[0m plain",
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("%q", lines)
+	}
+	for i := range lines {
+		if lines[i] != expected[i] {
+			t.Fatalf("%q", lines)
+		}
+	}
+}
+
+func TestANSICell(t *testing.T) {
+	table := NewTable("status")
+	table.AddRow("\x1b[32mok")
+
+	header :=
+		"+------+\n" +
+			"|status|\n" +
+			"+------+\n" +
+			"|\x1b[32mok\x1b[0m    |\n" +
+			"+------+\n"
+
+	tableStr := table.String()
+	t.Log(tableStr)
+	if tableStr != header {
+		t.Fail()
+	}
+}
@@ -1,21 +1,40 @@
 package clitable
 
-import (
-	"fmt"
-	"unicode/utf8"
-)
+import "fmt"
+
+// missingValue stands in for a value the caller did not provide, such as a
+// nil struct field or a key absent from a map.
+type missingValue struct{}
 
 type Cell struct {
-	data     string
-	parts    []string
-	partsLen int
-	width    int
+	value   interface{}
+	data    string
+	width   int
+	colspan int
+	covered bool
+	// missing is set for cells without a value, such as padding for a
+	// short row.
+	missing bool
+	// origin is the cell above whose row span covers this cell.
+	origin *Cell
+	Style  *TextStyle
 }
 
 func NewCell(data interface{}) *Cell {
 	str := fmt.Sprintf("%v", data)
 	return &Cell{
-		data:  str,
-		width: utf8.RuneCountInString(str),
+		value:   data,
+		data:    str,
+		width:   stringWidth(str),
+		colspan: 1,
+	}
+}
+
+// spanned returns the cell whose value c shows: the origin of the row span
+// c continues, or c itself.
+func (c *Cell) spanned() *Cell {
+	if c.origin != nil && !c.covered {
+		return c.origin
 	}
+	return c
 }
@@ -0,0 +1,264 @@
+package clitable
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+type ColorProfile int
+
+const (
+	ColorProfileAuto ColorProfile = iota
+	ColorProfileNone
+	ColorProfile16
+	ColorProfile256
+	ColorProfileTrueColor
+)
+
+type colorKind uint8
+
+const (
+	colorNone colorKind = iota
+	colorBasic
+	color256
+	colorRGB
+)
+
+// Color is a foreground or background color. The zero value means the
+// terminal default.
+type Color struct {
+	kind    colorKind
+	r, g, b uint8
+}
+
+var (
+	ColorBlack         = BasicColor(0)
+	ColorRed           = BasicColor(1)
+	ColorGreen         = BasicColor(2)
+	ColorYellow        = BasicColor(3)
+	ColorBlue          = BasicColor(4)
+	ColorMagenta       = BasicColor(5)
+	ColorCyan          = BasicColor(6)
+	ColorWhite         = BasicColor(7)
+	ColorBrightBlack   = BasicColor(8)
+	ColorBrightRed     = BasicColor(9)
+	ColorBrightGreen   = BasicColor(10)
+	ColorBrightYellow  = BasicColor(11)
+	ColorBrightBlue    = BasicColor(12)
+	ColorBrightMagenta = BasicColor(13)
+	ColorBrightCyan    = BasicColor(14)
+	ColorBrightWhite   = BasicColor(15)
+)
+
+var (
+	cubeLevels   = [6]uint8{0, 95, 135, 175, 215, 255}
+	basicPalette = [16][3]uint8{
+		{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+		{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+		{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+		{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+	}
+)
+
+// BasicColor returns one of the 16 standard terminal colors.
+func BasicColor(n uint8) Color {
+	return Color{kind: colorBasic, r: n % 16}
+}
+
+// Color256 returns a color from the xterm 256-color palette.
+func Color256(n uint8) Color {
+	return Color{kind: color256, r: n}
+}
+
+// RGBColor returns a 24-bit color.
+func RGBColor(r, g, b uint8) Color {
+	return Color{kind: colorRGB, r: r, g: g, b: b}
+}
+
+func (c Color) IsSet() bool {
+	return c.kind != colorNone
+}
+
+func (c Color) hex() string {
+	r, g, b := c.r, c.g, c.b
+	switch c.kind {
+	case colorBasic, color256:
+		r, g, b = ansi256ToRGB(c.r)
+	}
+	return fmt.Sprintf("#%02x%02x%02x", r, g, b)
+}
+
+func (c Color) downsample(profile ColorProfile) Color {
+	if c.kind == colorRGB && profile < ColorProfileTrueColor {
+		c = Color{kind: color256, r: rgbTo256(c.r, c.g, c.b)}
+	}
+	if c.kind == color256 && profile < ColorProfile256 {
+		c = Color{kind: colorBasic, r: ansi256To16(c.r)}
+	}
+	return c
+}
+
+func (c Color) params(profile ColorProfile, background bool) string {
+	c = c.downsample(profile)
+	switch c.kind {
+	case colorBasic:
+		base := 30
+		if c.r >= 8 {
+			base = 90
+		}
+		if background {
+			base += 10
+		}
+		return strconv.Itoa(base + int(c.r%8))
+	case color256:
+		if background {
+			return "48;5;" + strconv.Itoa(int(c.r))
+		}
+		return "38;5;" + strconv.Itoa(int(c.r))
+	case colorRGB:
+		prefix := "38;2;"
+		if background {
+			prefix = "48;2;"
+		}
+		return prefix + strconv.Itoa(int(c.r)) + ";" + strconv.Itoa(int(c.g)) + ";" + strconv.Itoa(int(c.b))
+	}
+	return ""
+}
+
+func cubeIndex(v uint8) int {
+	switch {
+	case v < 48:
+		return 0
+	case v < 115:
+		return 1
+	default:
+		return (int(v) - 35) / 40
+	}
+}
+
+func rgbTo256(r, g, b uint8) uint8 {
+	if r == g && g == b {
+		switch {
+		case r < 8:
+			return 16
+		case r > 248:
+			return 231
+		default:
+			return uint8(232 + (int(r)-8)*24/247)
+		}
+	}
+	return uint8(16 + 36*cubeIndex(r) + 6*cubeIndex(g) + cubeIndex(b))
+}
+
+func ansi256ToRGB(n uint8) (uint8, uint8, uint8) {
+	if n < 16 {
+		c := basicPalette[n]
+		return c[0], c[1], c[2]
+	}
+	if n >= 232 {
+		v := 8 + (n-232)*10
+		return v, v, v
+	}
+	n -= 16
+	return cubeLevels[n/36], cubeLevels[(n/6)%6], cubeLevels[n%6]
+}
+
+func ansi256To16(n uint8) uint8 {
+	if n < 16 {
+		return n
+	}
+	r, g, b := ansi256ToRGB(n)
+	best, bestDistance := 0, -1
+	for i, c := range basicPalette {
+		dr, dg, db := int(r)-int(c[0]), int(g)-int(c[1]), int(b)-int(c[2])
+		distance := dr*dr + dg*dg + db*db
+		if bestDistance < 0 || distance < bestDistance {
+			best, bestDistance = i, distance
+		}
+	}
+	return uint8(best)
+}
+
+func detectColorProfile() ColorProfile {
+	if os.Getenv("NO_COLOR") != "" {
+		return ColorProfileNone
+	}
+	switch strings.ToLower(os.Getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return ColorProfileTrueColor
+	}
+	term := os.Getenv("TERM")
+	switch {
+	case term == "" || term == "dumb":
+		return ColorProfileNone
+	case strings.Contains(term, "256color"):
+		return ColorProfile256
+	default:
+		return ColorProfile16
+	}
+}
+
+type TextStyle struct {
+	Foreground Color
+	Background Color
+	Bold       bool
+	Dim        bool
+	Italic     bool
+	Underline  bool
+	Reverse    bool
+}
+
+func (s TextStyle) merge(other *TextStyle) TextStyle {
+	if other == nil {
+		return s
+	}
+	if other.Foreground.IsSet() {
+		s.Foreground = other.Foreground
+	}
+	if other.Background.IsSet() {
+		s.Background = other.Background
+	}
+	s.Bold = s.Bold || other.Bold
+	s.Dim = s.Dim || other.Dim
+	s.Italic = s.Italic || other.Italic
+	s.Underline = s.Underline || other.Underline
+	s.Reverse = s.Reverse || other.Reverse
+	return s
+}
+
+func (s TextStyle) sgr(profile ColorProfile) string {
+	if profile <= ColorProfileNone {
+		return ""
+	}
+	params := make([]string, 0)
+	for _, attr := range []struct {
+		on    bool
+		param string
+	}{
+		{s.Bold, "1"},
+		{s.Dim, "2"},
+		{s.Italic, "3"},
+		{s.Underline, "4"},
+		{s.Reverse, "7"},
+	} {
+		if attr.on {
+			params = append(params, attr.param)
+		}
+	}
+	if s.Foreground.IsSet() {
+		params = append(params, s.Foreground.params(profile, false))
+	}
+	if s.Background.IsSet() {
+		params = append(params, s.Background.params(profile, true))
+	}
+	if len(params) == 0 {
+		return ""
+	}
+	return "\x1b[" + strings.Join(params, ";") + "m"
+}
+
+func (s TextStyle) backgroundSGR(profile ColorProfile) string {
+	return TextStyle{Background: s.Background}.sgr(profile)
+}
@@ -0,0 +1,68 @@
+package clitable
+
+import "testing"
+
+func TestTextStyleSGR(t *testing.T) {
+	style := TextStyle{
+		Foreground: RGBColor(255, 0, 0),
+		Background: ColorBlue,
+		Bold:       true,
+	}
+	cases := map[ColorProfile]string{
+		ColorProfileNone:      "",
+		ColorProfile16:        "\x1b[1;91;44m",
+		ColorProfile256:       "\x1b[1;38;5;196;44m",
+		ColorProfileTrueColor: "\x1b[1;38;2;255;0;0;44m",
+	}
+	for profile, expected := range cases {
+		if sgr := style.sgr(profile); sgr != expected {
+			t.Errorf("profile %d: %q, expected %q", profile, sgr, expected)
+		}
+	}
+}
+
+func TestDetectColorProfile(t *testing.T) {
+	t.Setenv("TERM", "xterm-256color")
+	t.Setenv("NO_COLOR", "1")
+	if profile := detectColorProfile(); profile != ColorProfileNone {
+		t.Errorf("profile = %d", profile)
+	}
+	t.Setenv("NO_COLOR", "")
+	if profile := detectColorProfile(); profile < ColorProfile256 {
+		t.Errorf("profile = %d", profile)
+	}
+}
+
+func TestStyledTable(t *testing.T) {
+	table := NewTable("id")
+	table.ColorProfile = ColorProfile16
+	table.Style = &TableStyle{
+		VerticalBorder:   "|",
+		HorizontalBorder: "-",
+		Corner:           "+",
+		BorderStyle:      &TextStyle{Foreground: ColorBrightBlack},
+	}
+	table.GetColumnByName("id").HeaderStyle = &ColumnStyle{
+		TextStyle: TextStyle{Bold: true},
+	}
+	row := table.AddRow(1)
+	row.GetCellByNum(0).Style = &TextStyle{Foreground: ColorGreen}
+
+	header :=
+		"\x1b[90m+--+\x1b[0m\n" +
+			"\x1b[90m|\x1b[0m\x1b[1mid\x1b[0m\x1b[90m|\x1b[0m\n" +
+			"\x1b[90m+--+\x1b[0m\n" +
+			"\x1b[90m|\x1b[0m\x1b[32m1\x1b[0m \x1b[90m|\x1b[0m\n" +
+			"\x1b[90m+--+\x1b[0m\n"
+
+	tableStr := table.String()
+	t.Log(tableStr)
+	if tableStr != header {
+		t.Errorf("%q", tableStr)
+	}
+
+	table.ColorProfile = ColorProfileNone
+	if table.String() != NewTable("id").String()+"|1 |\n+--+\n" {
+		t.Errorf("%q", table.String())
+	}
+}
@@ -1,7 +1,5 @@
 package clitable
 
-import "unicode/utf8"
-
 var (
 	defaultHeaderStyle = &ColumnStyle{
 		Align:         ColumnAlignCenter,
@@ -11,6 +9,10 @@ var (
 		Align:         ColumnAlignLeft,
 		VerticalAlign: ColumnVerticalAlignTop,
 	}
+	defaultFooterStyle = &ColumnStyle{
+		Align:         ColumnAlignLeft,
+		VerticalAlign: ColumnVerticalAlignTop,
+	}
 )
 
 type ColumnAlign int
@@ -19,6 +21,7 @@ const (
 	ColumnAlignLeft = iota
 	ColumnAlignCenter
 	ColumnAlignRight
+	ColumnAlignDecimal
 )
 
 type ColumnVerticalAlign int
@@ -36,25 +39,44 @@ type ColumnStyle struct {
 	PaddingRight  int
 	PaddingBottom int
 	PaddingLeft   int
+	// DecimalSeparator is used by ColumnAlignDecimal, "." if empty.
+	DecimalSeparator string
+	// AlignDecimalSuffix makes ColumnAlignDecimal also line up the text
+	// after the number, such as a unit. Otherwise it follows the number.
+	AlignDecimalSuffix bool
+	TextStyle
+}
+
+func (s *ColumnStyle) getDecimalSeparator() string {
+	return orDefault(s.DecimalSeparator, ".")
 }
 
 type Column struct {
+	name        string
 	width       int
 	HeaderStyle *ColumnStyle
 	BodyStyle   *ColumnStyle
+	FooterStyle *ColumnStyle
+	// Format, if set, turns body cell values into text instead of the
+	// default %v formatting. Blank cells are not formatted.
+	Format Formatter
 }
 
 func NewColumn(name string) *Column {
 	return &Column{
-		width:       utf8.RuneCountInString(name),
+		name:        name,
+		width:       stringWidth(name),
 		HeaderStyle: defaultHeaderStyle,
 		BodyStyle:   defaultBodyStyle,
+		FooterStyle: defaultFooterStyle,
 	}
 }
 
 func (c *Column) getStyleByRow(row *Row) *ColumnStyle {
 	if row.isHeader {
 		return c.HeaderStyle
+	} else if row.isFooter {
+		return c.FooterStyle
 	} else {
 		return c.BodyStyle
 	}
@@ -0,0 +1,67 @@
+package clitable
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+type CSVOptions struct {
+	// Comma is the field delimiter, ',' if zero.
+	Comma      rune
+	SkipHeader bool
+	UseCRLF    bool
+	// Formatted writes values as the columns' Format functions print
+	// them instead of the raw data.
+	Formatted bool
+	// IncludeFooters writes the rows added with AddFooter after the body.
+	IncludeFooters bool
+}
+
+func (t *Table) WriteCSV(w io.Writer, options *CSVOptions) (int64, error) {
+	if options == nil {
+		options = new(CSVOptions)
+	}
+	counter := &countWriter{w: w}
+	writer := csv.NewWriter(counter)
+	if options.Comma != 0 {
+		writer.Comma = options.Comma
+	}
+	writer.UseCRLF = options.UseCRLF
+	record := make([]string, len(t.columns))
+	rows := t.rows
+	if options.IncludeFooters {
+		rows = append(append([]*Row{}, t.rows...), t.footers...)
+	}
+	for _, row := range rows {
+		if row.isHeader && options.SkipHeader {
+			continue
+		}
+		for i := range row.cells {
+			record[i] = stripANSI(t.getCSVData(row, i, options.Formatted))
+		}
+		if err := writer.Write(record); err != nil {
+			return counter.n, err
+		}
+	}
+	writer.Flush()
+	return counter.n, writer.Error()
+}
+
+func (t *Table) WriteTSV(w io.Writer, options *CSVOptions) (int64, error) {
+	tsvOptions := CSVOptions{Comma: '\t'}
+	if options != nil {
+		tsvOptions.SkipHeader = options.SkipHeader
+		tsvOptions.UseCRLF = options.UseCRLF
+		tsvOptions.Formatted = options.Formatted
+		tsvOptions.IncludeFooters = options.IncludeFooters
+	}
+	return t.WriteCSV(w, &tsvOptions)
+}
+
+func (t *Table) getCSVData(row *Row, i int, formatted bool) string {
+	value := t.getCellValue(row, i)
+	if formatted || row.isHeader || isBlank(value) {
+		return t.getCellData(row, i)
+	}
+	return valueString(value)
+}
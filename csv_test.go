@@ -0,0 +1,76 @@
+package clitable
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCSV(t *testing.T) {
+	WinSize.Col = 10
+	defer func() { WinSize.Col = 0 }()
+	table := NewTable("id", "name", "description")
+	table.AddRow(1, "Smith, John", "says \"hi\"\nand leaves")
+	table.AddRow(2, "\x1b[1mbold\x1b[0m", "a long description that does not fit")
+
+	csv :=
+		"id,name,description\n" +
+			"1,\"Smith, John\",\"says \"\"hi\"\"\nand leaves\"\n" +
+			"2,bold,a long description that does not fit\n"
+
+	buf := new(bytes.Buffer)
+	n, err := table.WriteCSV(buf, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(buf.String())
+	if buf.String() != csv || n != int64(len(csv)) {
+		t.Fail()
+	}
+
+	tsv := "1\tSmith, John\t\"says \"\"hi\"\"\nand leaves\"\n" +
+		"2\tbold\ta long description that does not fit\n"
+
+	buf.Reset()
+	if _, err := table.WriteTSV(buf, &CSVOptions{SkipHeader: true}); err != nil {
+		t.Fatal(err)
+	}
+	t.Log(buf.String())
+	if buf.String() != tsv {
+		t.Fail()
+	}
+}
+
+func TestCSVFormatted(t *testing.T) {
+	table := NewTable("name", "price")
+	table.GetColumnByName("price").Format = FormatThousands(2, ",")
+	table.AddRow("car", 1234567.5)
+	table.AddRow("tea")
+
+	raw :=
+		"name,price\n" +
+			"car,1234567.5\n" +
+			"tea,\n"
+
+	buf := new(bytes.Buffer)
+	if _, err := table.WriteCSV(buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	t.Log(buf.String())
+	if buf.String() != raw {
+		t.Fail()
+	}
+
+	formatted :=
+		"name,price\n" +
+			"car,\"1,234,567.50\"\n" +
+			"tea,\n"
+
+	buf.Reset()
+	if _, err := table.WriteCSV(buf, &CSVOptions{Formatted: true}); err != nil {
+		t.Fatal(err)
+	}
+	t.Log(buf.String())
+	if buf.String() != formatted {
+		t.Fail()
+	}
+}
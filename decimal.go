@@ -0,0 +1,89 @@
+package clitable
+
+import "strings"
+
+type decimalCell struct {
+	cell                      *cellLayout
+	integer, fraction, suffix string
+	alignSuffix               bool
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// splitDecimal splits data into its integer part, its fraction part
+// starting with separator and the suffix following the number, such as a
+// unit. ok is false if data does not start with a number.
+func splitDecimal(data, separator string) (integer, fraction, suffix string, ok bool) {
+	i := 0
+	if i < len(data) && (data[i] == '-' || data[i] == '+') {
+		i++
+	}
+	for i < len(data) && !strings.HasPrefix(data[i:], separator) &&
+		(isDigit(data[i]) || strings.IndexByte(",_' ", data[i]) >= 0) {
+		i++
+	}
+	for i > 0 && !isDigit(data[i-1]) {
+		i--
+	}
+	integer = data[:i]
+	j := i
+	if strings.HasPrefix(data[j:], separator) {
+		j += len(separator)
+		for j < len(data) && isDigit(data[j]) {
+			j++
+		}
+	}
+	fraction = data[i:j]
+	if integer == "" && len(fraction) <= len(separator) {
+		return "", "", data, false
+	}
+	return integer, fraction, data[j:], true
+}
+
+// alignDecimals pads the cells of ColumnAlignDecimal columns so that their
+// decimal separators line up, and their suffixes with AlignDecimalSuffix.
+func (t *Table) alignDecimals(rows []*rowLayout) {
+	for i, column := range t.columns {
+		cells := make([]*decimalCell, 0)
+		integerWidth, fractionWidth, suffixWidth := 0, 0, 0
+		for _, rl := range rows {
+			style := column.getStyleByRow(rl.row)
+			if style.Align != ColumnAlignDecimal {
+				continue
+			}
+			cl := rl.cells[i]
+			if cl.span != 1 {
+				continue
+			}
+			integer, fraction, suffix, ok := splitDecimal(cl.lines[0], style.getDecimalSeparator())
+			if !ok {
+				continue
+			}
+			cells = append(cells, &decimalCell{cl, integer, fraction, suffix, style.AlignDecimalSuffix})
+			integerWidth = maxInt(integerWidth, stringWidth(integer))
+			fractionWidth = maxInt(fractionWidth, stringWidth(fraction))
+			suffixWidth = maxInt(suffixWidth, stringWidth(suffix))
+		}
+		for _, dc := range cells {
+			fractionPadding := strings.Repeat(WS, fractionWidth-stringWidth(dc.fraction))
+			suffixPadding := strings.Repeat(WS, suffixWidth-stringWidth(dc.suffix))
+			line := strings.Repeat(WS, integerWidth-stringWidth(dc.integer)) + dc.integer + dc.fraction
+			if dc.alignSuffix {
+				line += fractionPadding + dc.suffix + suffixPadding
+			} else {
+				line += dc.suffix + fractionPadding + suffixPadding
+			}
+			dc.cell.lines[0] = line
+			dc.cell.widths[0] = integerWidth + fractionWidth + suffixWidth
+		}
+	}
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,76 @@
+package clitable
+
+import "testing"
+
+func TestSplitDecimal(t *testing.T) {
+	cases := []struct {
+		data, integer, fraction, suffix string
+		ok                              bool
+	}{
+		{"123.25", "123", ".25", "", true},
+		{"-7", "-7", "", "", true},
+		{"1,234.5 MB", "1,234", ".5", " MB", true},
+		{"7 KB", "7", "", " KB", true},
+		{"n/a", "", "", "n/a", false},
+	}
+	for _, c := range cases {
+		integer, fraction, suffix, ok := splitDecimal(c.data, ".")
+		if integer != c.integer || fraction != c.fraction || suffix != c.suffix || ok != c.ok {
+			t.Errorf("%q: %q %q %q %v", c.data, integer, fraction, suffix, ok)
+		}
+	}
+}
+
+func TestDecimalAlign(t *testing.T) {
+	table := NewTable("value", "size")
+	for _, name := range []string{"value", "size"} {
+		table.GetColumnByName(name).BodyStyle = &ColumnStyle{Align: ColumnAlignDecimal}
+	}
+	table.AddRow(1.5, "1.5 GB")
+	table.AddRow(123.25, "20 MB")
+	table.AddRow(7, "n/a")
+
+	header :=
+		"+------+-------+\n" +
+			"|value | size  |\n" +
+			"+------+-------+\n" +
+			"|  1.5 | 1.5 GB|\n" +
+			"+------+-------+\n" +
+			"|123.25|20 MB  |\n" +
+			"+------+-------+\n" +
+			"|  7   |    n/a|\n" +
+			"+------+-------+\n"
+
+	tableStr := table.String()
+	t.Log(tableStr)
+	t.Log(header)
+	if tableStr != header {
+		t.Fail()
+	}
+}
+
+func TestDecimalAlignSuffix(t *testing.T) {
+	table := NewTable("size")
+	table.GetColumnByName("size").BodyStyle = &ColumnStyle{
+		Align:              ColumnAlignDecimal,
+		AlignDecimalSuffix: true,
+	}
+	table.AddRow("1.5 GB")
+	table.AddRow("20 MB")
+
+	header :=
+		"+-------+\n" +
+			"| size  |\n" +
+			"+-------+\n" +
+			"| 1.5 GB|\n" +
+			"+-------+\n" +
+			"|20   MB|\n" +
+			"+-------+\n"
+
+	tableStr := table.String()
+	t.Log(tableStr)
+	t.Log(header)
+	if tableStr != header {
+		t.Fail()
+	}
+}
@@ -0,0 +1,109 @@
+package clitable
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type RowMatcher func(row RowView) bool
+
+// Filter returns a table with the header and the body rows for which match
+// returns true, and the footers of t. The new table shares columns, styles
+// and rows with t; rows added to either table do not show in the other.
+func (t *Table) Filter(match RowMatcher) *Table {
+	filtered := &Table{
+		columns:      t.columns,
+		columnsMap:   t.columnsMap,
+		Style:        t.Style,
+		ColorProfile: t.ColorProfile,
+		Caption:      t.Caption,
+		RepeatHeader: t.RepeatHeader,
+		rows:         make([]*Row, 0, len(t.rows)),
+		footers:      append([]*Row{}, t.footers...),
+	}
+	for _, row := range t.rows {
+		if row.isHeader || match(RowView{table: t, row: row}) {
+			filtered.rows = append(filtered.rows, row)
+		}
+	}
+	return filtered
+}
+
+func Contains(column, substr string) RowMatcher {
+	return func(row RowView) bool {
+		data, ok := row.StringByName(column)
+		return ok && strings.Contains(stripANSI(data), substr)
+	}
+}
+
+// ContainsFold is like Contains but ignores Unicode case.
+func ContainsFold(column, substr string) RowMatcher {
+	substr = foldString(substr)
+	return func(row RowView) bool {
+		data, ok := row.StringByName(column)
+		return ok && strings.Contains(foldString(stripANSI(data)), substr)
+	}
+}
+
+func MatchRegexp(column string, re *regexp.Regexp) RowMatcher {
+	return func(row RowView) bool {
+		data, ok := row.StringByName(column)
+		return ok && re.MatchString(stripANSI(data))
+	}
+}
+
+func GreaterThan(column string, value float64) RowMatcher {
+	return compareNumber(column, func(n float64) bool { return n > value })
+}
+
+func GreaterOrEqual(column string, value float64) RowMatcher {
+	return compareNumber(column, func(n float64) bool { return n >= value })
+}
+
+func LessThan(column string, value float64) RowMatcher {
+	return compareNumber(column, func(n float64) bool { return n < value })
+}
+
+func LessOrEqual(column string, value float64) RowMatcher {
+	return compareNumber(column, func(n float64) bool { return n <= value })
+}
+
+func EqualTo(column string, value float64) RowMatcher {
+	return compareNumber(column, func(n float64) bool { return n == value })
+}
+
+// compareNumber matches rows whose cell in column is a number, or text that
+// parses as one, for which compare returns true.
+func compareNumber(column string, compare func(float64) bool) RowMatcher {
+	return func(row RowView) bool {
+		value, ok := row.ValueByName(column)
+		if !ok {
+			return false
+		}
+		n, ok := toFloat(value)
+		if !ok {
+			str, _ := row.StringByName(column)
+			var err error
+			if n, err = strconv.ParseFloat(strings.TrimSpace(stripANSI(str)), 64); err != nil {
+				return false
+			}
+		}
+		return compare(n)
+	}
+}
+
+// foldString maps every rune to the smallest rune of its Unicode case
+// folding orbit.
+func foldString(s string) string {
+	return strings.Map(func(r rune) rune {
+		folded := r
+		for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+			if f < folded {
+				folded = f
+			}
+		}
+		return folded
+	}, s)
+}
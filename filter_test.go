@@ -0,0 +1,77 @@
+package clitable
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func filteredNames(table *Table) string {
+	names := make([]string, 0)
+	for _, row := range table.View().Rows() {
+		name, _ := row.StringByName("name")
+		names = append(names, name)
+	}
+	return strings.Join(names, " ")
+}
+
+func TestFilter(t *testing.T) {
+	table := NewTable("name", "city", "cpu")
+	table.AddRow("web", "Москва", 12.5)
+	table.AddRow("db", "Berlin", "3")
+	table.AddRow("cache", "МОСКВА", "n/a")
+
+	cases := map[string]RowMatcher{
+		"web cache": ContainsFold("city", "москва"),
+		"web":       Contains("city", "Мос"),
+		"db cache":  MatchRegexp("name", regexp.MustCompile("^(db|cache)$")),
+		"db":        LessThan("cpu", 10),
+		"web db":    GreaterOrEqual("cpu", 3),
+		"":          EqualTo("missing", 1),
+	}
+	for expected, match := range cases {
+		if names := filteredNames(table.Filter(match)); names != expected {
+			t.Errorf("%q, expected %q", names, expected)
+		}
+	}
+
+	filtered := table.Filter(Contains("name", "web"))
+	header :=
+		"+----+------+----+\n" +
+			"|name| city |cpu |\n" +
+			"+----+------+----+\n" +
+			"|web |Москва|12.5|\n" +
+			"+----+------+----+\n"
+	if filtered.String() != header {
+		t.Error(filtered.String())
+	}
+	if len(table.rows) != 4 {
+		t.Fail()
+	}
+}
+
+func TestFilterIndependent(t *testing.T) {
+	table := NewTable("name")
+	table.AddRow("web")
+	table.AddFooter("total")
+	table.AddSeparator()
+
+	filtered := table.Filter(Contains("name", "web"))
+	filtered.AddFooter("filtered")
+	table.AddFooter("original")
+	filtered.AddRow("db")
+
+	if n := len(table.View().Footers()); n != 2 {
+		t.Errorf("original footers = %d", n)
+	}
+	if names := filteredNames(table); names != "web" {
+		t.Errorf("original rows = %q", names)
+	}
+	footers := filtered.View().Footers()
+	if len(footers) != 2 || footers[1].String(0) != "filtered" {
+		t.Errorf("filtered footers = %d", len(footers))
+	}
+	if filtered.rows[2].sectionBreak {
+		t.Error("filtered table took the pending section break")
+	}
+}
@@ -0,0 +1,116 @@
+package clitable
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Formatter func(value interface{}) string
+
+func isBlank(value interface{}) bool {
+	return value == nil || value == ""
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return 0, false
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
+
+// valueString prints value with %v, and floats without an exponent.
+func valueString(value interface{}) string {
+	if f, ok := value.(float64); ok {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
+
+// FormatDecimal prints numbers with a fixed number of decimal places.
+func FormatDecimal(precision int) Formatter {
+	return func(value interface{}) string {
+		if f, ok := toFloat(value); ok {
+			return strconv.FormatFloat(f, 'f', precision, 64)
+		}
+		return fmt.Sprintf("%v", value)
+	}
+}
+
+// FormatThousands prints numbers with a fixed number of decimal places and
+// the integer part grouped by separator, e.g. 1,234,567.89.
+func FormatThousands(precision int, separator string) Formatter {
+	return func(value interface{}) string {
+		f, ok := toFloat(value)
+		if !ok {
+			return fmt.Sprintf("%v", value)
+		}
+		str := strconv.FormatFloat(f, 'f', precision, 64)
+		sign := ""
+		if strings.HasPrefix(str, "-") {
+			sign, str = "-", str[1:]
+		}
+		integer, fraction := str, ""
+		if i := strings.Index(str, "."); i >= 0 {
+			integer, fraction = str[:i], str[i:]
+		}
+		buf := new(strings.Builder)
+		for i, digit := range integer {
+			if i > 0 && (len(integer)-i)%3 == 0 {
+				buf.WriteString(separator)
+			}
+			buf.WriteRune(digit)
+		}
+		return sign + buf.String() + fraction
+	}
+}
+
+// FormatPercent prints a ratio as a percentage, so 0.25 becomes 25%.
+func FormatPercent(precision int) Formatter {
+	return func(value interface{}) string {
+		if f, ok := toFloat(value); ok {
+			return strconv.FormatFloat(f*100, 'f', precision, 64) + "%"
+		}
+		return fmt.Sprintf("%v", value)
+	}
+}
+
+// FormatTime prints time.Time values using layout.
+func FormatTime(layout string) Formatter {
+	return func(value interface{}) string {
+		switch v := value.(type) {
+		case time.Time:
+			return v.Format(layout)
+		case *time.Time:
+			if v != nil {
+				return v.Format(layout)
+			}
+			return ""
+		}
+		return fmt.Sprintf("%v", value)
+	}
+}
+
+// FormatDuration prints time.Duration values rounded to round.
+func FormatDuration(round time.Duration) Formatter {
+	return func(value interface{}) string {
+		if d, ok := value.(time.Duration); ok {
+			return d.Round(round).String()
+		}
+		return fmt.Sprintf("%v", value)
+	}
+}
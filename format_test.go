@@ -0,0 +1,59 @@
+package clitable
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestFormatters(t *testing.T) {
+	cases := []struct {
+		format   Formatter
+		value    interface{}
+		expected string
+	}{
+		{FormatDecimal(2), 10.0 / 3, "3.33"},
+		{FormatDecimal(1), 7, "7.0"},
+		{FormatDecimal(2), "n/a", "n/a"},
+		{FormatThousands(0, ","), 1234567, "1,234,567"},
+		{FormatThousands(2, " "), -1234.5, "-1 234.50"},
+		{FormatThousands(0, ","), 123, "123"},
+		{FormatPercent(1), 0.256, "25.6%"},
+		{FormatTime("2006-01-02"), time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), "2020-01-02"},
+		{FormatDuration(time.Second), 1500 * time.Millisecond, "2s"},
+	}
+	for _, c := range cases {
+		if str := c.format(c.value); str != c.expected {
+			t.Errorf("%v: %q, expected %q", c.value, str, c.expected)
+		}
+	}
+}
+
+func TestColumnFormat(t *testing.T) {
+	table := NewTable("name", "price")
+	table.GetColumnByName("price").Format = FormatDecimal(2)
+	table.AddRow("tea", 10.0/3)
+	table.AddRow("water")
+
+	header :=
+		"+-----+-----+\n" +
+			"|name |price|\n" +
+			"+-----+-----+\n" +
+			"|tea  |3.33 |\n" +
+			"+-----+-----+\n" +
+			"|water|     |\n" +
+			"+-----+-----+\n"
+
+	tableStr := table.String()
+	t.Log(tableStr)
+	t.Log(header)
+	if tableStr != header {
+		t.Fail()
+	}
+
+	buf := new(bytes.Buffer)
+	table.WriteJSON(buf)
+	if buf.String() != "[\n  {\"name\":\"tea\",\"price\":3.3333333333333335},\n  {\"name\":\"water\",\"price\":null}\n]\n" {
+		t.Error(buf.String())
+	}
+}
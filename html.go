@@ -0,0 +1,141 @@
+package clitable
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+var (
+	htmlAligns = map[ColumnAlign]string{
+		ColumnAlignLeft:    "left",
+		ColumnAlignCenter:  "center",
+		ColumnAlignRight:   "right",
+		ColumnAlignDecimal: "right",
+	}
+	htmlVerticalAligns = map[ColumnVerticalAlign]string{
+		ColumnVerticalAlignTop:    "top",
+		ColumnVerticalAlignMiddle: "middle",
+		ColumnVerticalAlignBottom: "bottom",
+	}
+	htmlNewLines = strings.NewReplacer("\r\n", "<br>", "\n", "<br>", "\r", "<br>")
+)
+
+func (t *Table) HTML() string {
+	buf := new(bytes.Buffer)
+	t.WriteHTML(buf)
+	return buf.String()
+}
+
+func (t *Table) WriteHTML(w io.Writer) (int64, error) {
+	buf := newTableWriter(w)
+	buf.WriteString("<table>")
+	buf.Write(EOL)
+	if t.Caption != "" {
+		buf.WriteString("<caption>" + escapeHTML(t.Caption) + "</caption>")
+		buf.Write(EOL)
+	}
+	for _, section := range []struct {
+		tag  string
+		rows []*Row
+	}{
+		{"thead", t.rows[:1]},
+		{"tbody", t.rows[1:]},
+		{"tfoot", t.footers},
+	} {
+		if len(section.rows) == 0 {
+			continue
+		}
+		buf.WriteString("<" + section.tag + ">")
+		buf.Write(EOL)
+		for y := range section.rows {
+			t.writeHTMLRow(buf, section.rows, y)
+			if err := buf.Flush(); err != nil {
+				return buf.Count(), err
+			}
+		}
+		buf.WriteString("</" + section.tag + ">")
+		buf.Write(EOL)
+	}
+	buf.WriteString("</table>")
+	buf.Write(EOL)
+	err := buf.Flush()
+	return buf.Count(), err
+}
+
+// writeHTMLRow writes rows[y]. Row spans are counted within rows, the rows
+// of one section.
+func (t *Table) writeHTMLRow(buf *tableWriter, rows []*Row, y int) {
+	row := rows[y]
+	var above *Row
+	if y > 0 {
+		above = rows[y-1]
+	}
+	tag := "td"
+	if row.isHeader {
+		tag = "th"
+	}
+	buf.WriteString("<tr>")
+	for i, cell := range row.cells {
+		if cell.covered || continuesSpan(above, row, i) {
+			continue
+		}
+		style := t.columns[i].getStyleByRow(row)
+		data := t.getCellData(row, i)
+		text := style.TextStyle.merge(row.Style).merge(cell.spanned().Style)
+		span := ""
+		if cell.colspan > 1 {
+			span = fmt.Sprintf(" colspan=\"%d\"", cell.colspan)
+		}
+		rowspan := 1
+		for rowspan < len(rows)-y && continuesSpan(rows[y+rowspan-1], rows[y+rowspan], i) {
+			rowspan++
+		}
+		if rowspan > 1 {
+			span += fmt.Sprintf(" rowspan=\"%d\"", rowspan)
+		}
+		buf.WriteString(fmt.Sprintf("<%s%s style=\"%s\">", tag, span, style.css(text)))
+		buf.WriteString(escapeHTML(data))
+		buf.WriteString("</" + tag + ">")
+	}
+	buf.WriteString("</tr>")
+	buf.Write(EOL)
+}
+
+func escapeHTML(data string) string {
+	return htmlNewLines.Replace(html.EscapeString(stripANSI(data)))
+}
+
+func (s *ColumnStyle) css(text TextStyle) string {
+	rules := []string{
+		"text-align: " + htmlAligns[s.Align],
+		"vertical-align: " + htmlVerticalAligns[s.VerticalAlign],
+	}
+	if s.PaddingTop != 0 || s.PaddingRight != 0 || s.PaddingBottom != 0 || s.PaddingLeft != 0 {
+		rules = append(rules, fmt.Sprintf(
+			"padding: %dem %dch %dem %dch",
+			s.PaddingTop, s.PaddingRight, s.PaddingBottom, s.PaddingLeft,
+		))
+	}
+	if text.Foreground.IsSet() {
+		rules = append(rules, "color: "+text.Foreground.hex())
+	}
+	if text.Background.IsSet() {
+		rules = append(rules, "background-color: "+text.Background.hex())
+	}
+	if text.Bold {
+		rules = append(rules, "font-weight: bold")
+	}
+	if text.Dim {
+		rules = append(rules, "opacity: 0.5")
+	}
+	if text.Italic {
+		rules = append(rules, "font-style: italic")
+	}
+	if text.Underline {
+		rules = append(rules, "text-decoration: underline")
+	}
+	return strings.Join(rules, "; ")
+}
@@ -0,0 +1,32 @@
+package clitable
+
+import "testing"
+
+func TestHTML(t *testing.T) {
+	table := NewTable("id", "name")
+	table.Caption = "Users & groups"
+	table.GetColumnByName("id").BodyStyle = &ColumnStyle{
+		Align:        ColumnAlignRight,
+		PaddingLeft:  1,
+		PaddingRight: 1,
+	}
+	row := table.AddRow(1, "<admin>\nroot")
+	row.Style = &TextStyle{Bold: true, Foreground: ColorRed}
+
+	html :=
+		"<table>\n" +
+			"<caption>Users &amp; groups</caption>\n" +
+			"<thead>\n" +
+			"<tr><th style=\"text-align: center; vertical-align: middle\">id</th><th style=\"text-align: center; vertical-align: middle\">name</th></tr>\n" +
+			"</thead>\n" +
+			"<tbody>\n" +
+			"<tr><td style=\"text-align: right; vertical-align: top; padding: 0em 1ch 0em 1ch; color: #cd0000; font-weight: bold\">1</td><td style=\"text-align: left; vertical-align: top; color: #cd0000; font-weight: bold\">&lt;admin&gt;<br>root</td></tr>\n" +
+			"</tbody>\n" +
+			"</table>\n"
+
+	htmlStr := table.HTML()
+	t.Log(htmlStr)
+	if htmlStr != html {
+		t.Errorf("%q", htmlStr)
+	}
+}
@@ -17,12 +17,14 @@ var (
 	EOL = []byte{'\n'}
 	WS  = " "
 
-	WinSize     *WindowSize
-	_TIOCGWINSZ int64
+	WinSize             *WindowSize
+	DefaultColorProfile ColorProfile
+	_TIOCGWINSZ         int64
 )
 
 func init() {
 	WinSize = new(WindowSize)
+	DefaultColorProfile = detectColorProfile()
 
 	switch runtime.GOOS {
 	case "linux":
@@ -0,0 +1,89 @@
+package clitable
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+)
+
+// WriteJSON writes the body rows as an array of objects keyed by column
+// name. Footer rows are not written.
+func (t *Table) WriteJSON(w io.Writer) (int64, error) {
+	return t.writeJSON(w, false)
+}
+
+// WriteNDJSON writes the body rows as one object per line, like WriteJSON.
+func (t *Table) WriteNDJSON(w io.Writer) (int64, error) {
+	return t.writeJSON(w, true)
+}
+
+func (t *Table) writeJSON(w io.Writer, lines bool) (int64, error) {
+	buf := newTableWriter(w)
+	if !lines {
+		buf.WriteString("[")
+	}
+	first := true
+	for _, row := range t.rows {
+		if row.isHeader {
+			continue
+		}
+		object, err := t.marshalRow(row)
+		if err != nil {
+			buf.Flush()
+			return buf.Count(), err
+		}
+		if !lines {
+			if !first {
+				buf.WriteString(",")
+			}
+			buf.WriteString("\n  ")
+		}
+		buf.Write(object)
+		if lines {
+			buf.WriteString("\n")
+		}
+		first = false
+		if err := buf.Flush(); err != nil {
+			return buf.Count(), err
+		}
+	}
+	if !lines {
+		if !first {
+			buf.WriteString("\n")
+		}
+		buf.WriteString("]\n")
+	}
+	err := buf.Flush()
+	return buf.Count(), err
+}
+
+func (t *Table) marshalRow(row *Row) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	buf.WriteString("{")
+	for i, cell := range row.cells {
+		cell = cell.spanned()
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		key, err := json.Marshal(t.columns[i].name)
+		if err != nil {
+			return nil, err
+		}
+		var value interface{}
+		if !cell.missing {
+			value = cell.value
+		}
+		if str, ok := value.(string); ok {
+			value = stripANSI(str)
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteString(":")
+		buf.Write(data)
+	}
+	buf.WriteString("}")
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,72 @@
+package clitable
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestJSON(t *testing.T) {
+	table := NewTable("id", "name", "score", "created")
+	table.AddRow(1, "\x1b[1mfirst\x1b[0m", 3.5, time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC))
+	table.AddRow(2, "second")
+
+	json :=
+		"[\n" +
+			"  {\"id\":1,\"name\":\"first\",\"score\":3.5,\"created\":\"2020-01-02T03:04:05Z\"},\n" +
+			"  {\"id\":2,\"name\":\"second\",\"score\":null,\"created\":null}\n" +
+			"]\n"
+
+	buf := new(bytes.Buffer)
+	if _, err := table.WriteJSON(buf); err != nil {
+		t.Fatal(err)
+	}
+	t.Log(buf.String())
+	if buf.String() != json {
+		t.Fail()
+	}
+
+	ndjson :=
+		"{\"id\":1,\"name\":\"first\",\"score\":3.5,\"created\":\"2020-01-02T03:04:05Z\"}\n" +
+			"{\"id\":2,\"name\":\"second\",\"score\":null,\"created\":null}\n"
+
+	buf.Reset()
+	if _, err := table.WriteNDJSON(buf); err != nil {
+		t.Fatal(err)
+	}
+	t.Log(buf.String())
+	if buf.String() != ndjson {
+		t.Fail()
+	}
+}
+
+func TestJSONUnsupportedValue(t *testing.T) {
+	table := NewTable("fn")
+	table.AddRow(func() {})
+	if _, err := table.WriteJSON(new(bytes.Buffer)); err == nil {
+		t.Fail()
+	}
+}
+
+func TestJSONMissingValues(t *testing.T) {
+	table, err := NewTableFromMaps([]map[string]interface{}{
+		{"id": 1, "name": "", "score": 3.5},
+		{"id": 2},
+	}, &MapOptions{Columns: []string{"id", "name", "score"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ndjson :=
+		"{\"id\":1,\"name\":\"\",\"score\":3.5}\n" +
+			"{\"id\":2,\"name\":null,\"score\":null}\n"
+
+	buf := new(bytes.Buffer)
+	if _, err := table.WriteNDJSON(buf); err != nil {
+		t.Fatal(err)
+	}
+	t.Log(buf.String())
+	if buf.String() != ndjson {
+		t.Fail()
+	}
+}
@@ -0,0 +1,277 @@
+package clitable
+
+import (
+	"bytes"
+	"math"
+	"strings"
+)
+
+type cellLayout struct {
+	span int
+	// rows is the number of rows the cell spans, and origin the first cell
+	// of the row span that a cell continues.
+	rows       int
+	origin     *cellLayout
+	lines      []string
+	widths     []int
+	open       string
+	background string
+}
+
+type rowLayout struct {
+	row    *Row
+	height int
+	cells  []*cellLayout
+}
+
+type layout struct {
+	widths    []int
+	rows      []*rowLayout
+	footers   []*rowLayout
+	borderSGR string
+	// separatorWidth is the width of the border between two columns.
+	separatorWidth int
+}
+
+func (t *Table) layout() *layout {
+	l := &layout{
+		widths:         make([]int, len(t.columns)),
+		borderSGR:      t.getBorderSGR(),
+		separatorWidth: stringWidth(t.Style.columnSeparator()),
+	}
+	profile := t.getColorProfile()
+	for i, column := range t.columns {
+		l.widths[i] = column.width
+	}
+	rows := append(append([]*Row{}, t.rows...), t.footers...)
+	all := make([]*rowLayout, len(rows))
+	l.rows, l.footers = all[:len(t.rows)], all[len(t.rows):]
+	for y, row := range rows {
+		rl := &rowLayout{
+			row:    row,
+			height: 1,
+			cells:  make([]*cellLayout, len(row.cells)),
+		}
+		for i, cell := range row.cells {
+			data, width := cell.data, cell.width
+			if formatted := t.getCellData(row, i); formatted != data {
+				data, width = formatted, stringWidth(formatted)
+			}
+			span := cell.colspan
+			if cell.covered {
+				span = 0
+			}
+			cl := &cellLayout{
+				span:   span,
+				rows:   1,
+				lines:  []string{data},
+				widths: []int{width},
+			}
+			if !cell.covered && y > 0 && continuesSpan(rows[y-1], row, i) {
+				head := all[y-1].cells[i]
+				if head.origin != nil {
+					head = head.origin
+				}
+				cl.origin = head
+				head.rows++
+			}
+			rl.cells[i] = cl
+		}
+		all[y] = rl
+	}
+
+	t.alignDecimals(all)
+
+	for _, rl := range all {
+		for i, cl := range rl.cells {
+			if cl.span != 1 {
+				continue
+			}
+			style := t.columns[i].getStyleByRow(rl.row)
+			columnWidth := cl.widths[0] + style.PaddingLeft + style.PaddingRight
+			if l.widths[i] < columnWidth {
+				l.widths[i] = columnWidth
+			}
+		}
+	}
+
+	t.widenSpans(l, all)
+	t.shrinkWidths(l.widths)
+
+	for _, rl := range all {
+		row := rl.row
+		for i, cell := range row.cells {
+			cl := rl.cells[i]
+			if cl.span == 0 {
+				continue
+			}
+			style := t.columns[i].getStyleByRow(row)
+			columnWidth := l.spanWidth(i, cl.span) - (style.PaddingLeft + style.PaddingRight)
+			if data := cl.lines[0]; cl.widths[0] > columnWidth {
+				if parts := wrap(data, columnWidth); len(parts) > 1 {
+					cl.lines = parts
+					cl.widths = make([]int, len(parts))
+					for j, part := range parts {
+						cl.widths[j] = stringWidth(part)
+					}
+				}
+			}
+			cl.lines = closeStyles(cl.lines)
+			text := style.TextStyle.merge(row.Style).merge(cell.spanned().Style)
+			cl.open = text.sgr(profile)
+			cl.background = text.backgroundSGR(profile)
+			nextHeight := len(cl.lines) + style.PaddingTop + style.PaddingBottom
+			if nextHeight > rl.height && cl.rows == 1 && cl.origin == nil {
+				rl.height = nextHeight
+			}
+		}
+	}
+	_, lines := t.separators(l.rows)
+	t.growRowSpans(l.rows, lines)
+	return l
+}
+
+// growRowSpans makes the last row under a row-spanning cell taller when
+// the cell does not fit into the rows it spans and the separator lines
+// between them.
+func (t *Table) growRowSpans(rows []*rowLayout, lines []bool) {
+	for y, rl := range rows {
+		for i, cl := range rl.cells {
+			if cl.rows < 2 || cl.origin != nil {
+				continue
+			}
+			style := t.columns[i].getStyleByRow(rl.row)
+			height := 0
+			for m, spanned := range rows[y : y+cl.rows] {
+				height += spanned.height
+				if m > 0 && lines[y+m] {
+					height++
+				}
+			}
+			if need := len(cl.lines) + style.PaddingTop + style.PaddingBottom; need > height {
+				rows[y+cl.rows-1].height += need - height
+			}
+		}
+	}
+}
+
+func (t *Table) shrinkWidths(widths []int) {
+	maxRowWidth := 0
+	for _, width := range widths {
+		maxRowWidth += width
+	}
+
+	fullRowWidth := maxRowWidth + t.Style.chromeWidth(len(widths))
+	winCol := int(WinSize.Col)
+
+	if fullRowWidth <= winCol || winCol <= 0 {
+		return
+	}
+
+	excess := float64(fullRowWidth-winCol) + 5
+	maxExcess := excess
+	meanColumnWidth := float64(maxRowWidth) / float64(len(widths))
+	maxWidth := 0
+	maxWidthColumn := 0
+	var currentRate float64
+	for i := range widths {
+		rate := (100 * float64(widths[i])) / float64(maxRowWidth)
+		currentRate += rate
+		if float64(widths[i])+maxExcess-excess > meanColumnWidth {
+			excessColumn := excess * currentRate / 100
+			widths[i] -= int(math.Floor(excessColumn))
+			excess -= excessColumn
+		}
+		if maxWidth < widths[i] {
+			maxWidth = widths[i]
+			maxWidthColumn = i
+		}
+	}
+	if excess > 0 {
+		widths[maxWidthColumn] -= int(math.Floor(excess))
+	}
+}
+
+func wrap(data string, width int) []string {
+	srcParts := strings.Split(data, WS)
+	lastStrPart := len(srcParts) - 1
+	dstParts := make([]string, 0)
+	cellBuf := new(bytes.Buffer)
+	cellBufLen := 0
+	flush := func() {
+		if cellBuf.Len() > 0 {
+			dstParts = append(dstParts, strings.TrimRight(cellBuf.String(), WS))
+			cellBuf.Reset()
+			cellBufLen = 0
+		}
+	}
+	write := func(part string, partLen int) {
+		cellBuf.WriteString(part)
+		cellBufLen += partLen
+		if cellBufLen+1 < width {
+			cellBuf.WriteString(WS)
+			cellBufLen++
+		}
+	}
+	for j, srcPart := range srcParts {
+		srcPartLen := stringWidth(srcPart)
+		if srcPartLen > width {
+			flush()
+			chunks := breakWord(srcPart, width)
+			lastChunk := chunks[len(chunks)-1]
+			dstParts = append(dstParts, chunks[:len(chunks)-1]...)
+			write(lastChunk, stringWidth(lastChunk))
+		} else if cellBufLen+srcPartLen < width {
+			write(srcPart, srcPartLen)
+		} else {
+			flush()
+			write(srcPart, srcPartLen)
+		}
+		if j == lastStrPart {
+			flush()
+		}
+	}
+	return dstParts
+}
+
+func breakWord(word string, width int) []string {
+	chunks := make([]string, 0)
+	start, end, chunkLen := 0, 0, 0
+	eachGrapheme(word, func(grapheme string, graphemeLen int) {
+		if chunkLen+graphemeLen > width && end > start {
+			chunks = append(chunks, word[start:end])
+			start, chunkLen = end, 0
+		}
+		end += len(grapheme)
+		chunkLen += graphemeLen
+	})
+	return append(chunks, word[start:end])
+}
+
+func (c *cellLayout) styled(j int) string {
+	line := c.lines[j]
+	if c.open == "" {
+		return line
+	}
+	line = strings.ReplaceAll(line, ansiReset, ansiReset+c.open)
+	line = strings.ReplaceAll(line, "\x1b[m", ansiReset+c.open)
+	return c.open + line + ansiReset + c.background
+}
+
+func (c *cellLayout) start(style *ColumnStyle, height int) int {
+	linesLen := len(c.lines)
+	switch style.VerticalAlign {
+	case ColumnVerticalAlignMiddle:
+		if linesLen > 1 {
+			return (height-linesLen)/2 + style.PaddingTop
+		}
+		return (height-(style.PaddingTop+style.PaddingBottom))/2 + style.PaddingTop
+	case ColumnVerticalAlignBottom:
+		if linesLen > 1 {
+			return height - linesLen
+		}
+		return height - 1 - style.PaddingBottom
+	default:
+		return style.PaddingTop
+	}
+}
@@ -0,0 +1,121 @@
+package clitable
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+type MapColumnOrder int
+
+const (
+	// MapColumnsFirstSeen orders columns by the row in which a key first
+	// appears; keys first seen in the same row are sorted.
+	MapColumnsFirstSeen MapColumnOrder = iota
+	MapColumnsSorted
+)
+
+type MapOptions struct {
+	Order MapColumnOrder
+	// Columns, if set, selects the columns and their order.
+	Columns []string
+}
+
+// NewTableFromMaps builds a table from a slice of maps, such as
+// []map[string]interface{} or decoded JSON. Missing keys give empty cells.
+func NewTableFromMaps(rows interface{}, options *MapOptions) (*Table, error) {
+	if options == nil {
+		options = new(MapOptions)
+	}
+	value := reflect.ValueOf(rows)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return nil, fmt.Errorf("%w %T: expected a slice of maps", ErrUnsupportedType, rows)
+	}
+	maps := make([]reflect.Value, value.Len())
+	for y := range maps {
+		m := value.Index(y)
+		for m.Kind() == reflect.Interface || m.Kind() == reflect.Ptr {
+			m = m.Elem()
+		}
+		if m.Kind() != reflect.Map && m.IsValid() {
+			return nil, fmt.Errorf("%w %s at row %d: expected a map", ErrUnsupportedType, m.Type(), y)
+		}
+		maps[y] = m
+	}
+
+	columns := options.Columns
+	if len(columns) == 0 {
+		columns = mapColumns(maps, options.Order)
+	}
+	names := make([]interface{}, len(columns))
+	for i, column := range columns {
+		names[i] = column
+	}
+	table := NewTable(names...)
+
+	datas := make([]interface{}, len(columns))
+	for _, m := range maps {
+		values := make(map[string]interface{})
+		if m.IsValid() {
+			for _, key := range m.MapKeys() {
+				values[fmt.Sprint(key.Interface())] = m.MapIndex(key).Interface()
+			}
+		}
+		for i, column := range columns {
+			if data, ok := values[column]; ok && data != nil {
+				datas[i] = data
+			} else {
+				datas[i] = missingValue{}
+			}
+		}
+		table.AddRow(datas...)
+	}
+	return table, nil
+}
+
+func mapColumns(maps []reflect.Value, order MapColumnOrder) []string {
+	columns := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, m := range maps {
+		if !m.IsValid() {
+			continue
+		}
+		for _, key := range sortedMapKeys(m) {
+			if !seen[key] {
+				seen[key] = true
+				columns = append(columns, key)
+			}
+		}
+	}
+	if order == MapColumnsSorted {
+		sort.Strings(columns)
+	}
+	return columns
+}
+
+func sortedMapKeys(m reflect.Value) []string {
+	keys := make([]string, 0, m.Len())
+	for _, key := range m.MapKeys() {
+		keys = append(keys, fmt.Sprint(key.Interface()))
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// NewTableFromMap builds a two-column key/value table from a map, sorted
+// by key.
+func NewTableFromMap(m interface{}) (*Table, error) {
+	value := reflect.ValueOf(m)
+	if value.Kind() != reflect.Map {
+		return nil, fmt.Errorf("%w %T: expected a map", ErrUnsupportedType, m)
+	}
+	values := make(map[string]interface{}, value.Len())
+	for _, key := range value.MapKeys() {
+		values[fmt.Sprint(key.Interface())] = value.MapIndex(key).Interface()
+	}
+	table := NewTable("Key", "Value")
+	for _, key := range sortedMapKeys(value) {
+		table.AddRow(key, values[key])
+	}
+	return table, nil
+}
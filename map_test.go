@@ -0,0 +1,73 @@
+package clitable
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewTableFromMaps(t *testing.T) {
+	rows := []interface{}{
+		map[string]interface{}{"name": "web", "id": 1},
+		map[string]interface{}{"id": 2, "region": "eu"},
+	}
+
+	table, err := NewTableFromMaps(rows, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header :=
+		"+--+----+------+\n" +
+			"|id|name|region|\n" +
+			"+--+----+------+\n" +
+			"|1 |web |      |\n" +
+			"+--+----+------+\n" +
+			"|2 |    |eu    |\n" +
+			"+--+----+------+\n"
+	tableStr := table.String()
+	t.Log(tableStr)
+	if tableStr != header {
+		t.Fail()
+	}
+
+	table, err = NewTableFromMaps(rows, &MapOptions{Columns: []string{"region", "id"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	header =
+		"+------+--+\n" +
+			"|region|id|\n" +
+			"+------+--+\n" +
+			"|      |1 |\n" +
+			"+------+--+\n" +
+			"|eu    |2 |\n" +
+			"+------+--+\n"
+	tableStr = table.String()
+	t.Log(tableStr)
+	if tableStr != header {
+		t.Fail()
+	}
+
+	if _, err := NewTableFromMaps([]int{1}, nil); !errors.Is(err, ErrUnsupportedType) {
+		t.Error(err)
+	}
+}
+
+func TestNewTableFromMap(t *testing.T) {
+	table, err := NewTableFromMap(map[string]int{"b": 2, "a": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	header :=
+		"+---+-----+\n" +
+			"|Key|Value|\n" +
+			"+---+-----+\n" +
+			"|a  |1    |\n" +
+			"+---+-----+\n" +
+			"|b  |2    |\n" +
+			"+---+-----+\n"
+	tableStr := table.String()
+	t.Log(tableStr)
+	if tableStr != header {
+		t.Fail()
+	}
+}
@@ -0,0 +1,75 @@
+package clitable
+
+import (
+	"bytes"
+	"io"
+	"strings"
+)
+
+var markdownEscaper = strings.NewReplacer(
+	"|", "\\|",
+	"\r\n", "<br>",
+	"\n", "<br>",
+	"\r", "<br>",
+)
+
+func (t *Table) Markdown() string {
+	buf := new(bytes.Buffer)
+	t.WriteMarkdown(buf)
+	return buf.String()
+}
+
+func (t *Table) WriteMarkdown(w io.Writer) (int64, error) {
+	widths := make([]int, len(t.columns))
+	for i := range widths {
+		widths[i] = 3
+	}
+	tableRows := append(append([]*Row{}, t.rows...), t.footers...)
+	rows := make([][]string, len(tableRows))
+	for y, row := range tableRows {
+		rows[y] = make([]string, len(row.cells))
+		for i := range row.cells {
+			data := markdownEscaper.Replace(stripANSI(t.getCellData(row, i)))
+			if width := stringWidth(data); width > widths[i] {
+				widths[i] = width
+			}
+			rows[y][i] = data
+		}
+	}
+
+	buf := newTableWriter(w)
+	for y, row := range tableRows {
+		buf.WriteString("|")
+		for i, data := range rows[y] {
+			buf.WriteString(WS)
+			t.writeCell(buf, widths[i], stringWidth(data), data, &ColumnStyle{Align: t.columns[i].BodyStyle.Align})
+			buf.WriteString(" |")
+		}
+		buf.Write(EOL)
+		if row.isHeader {
+			t.writeMarkdownDelimiter(buf, widths)
+		}
+		if err := buf.Flush(); err != nil {
+			return buf.Count(), err
+		}
+	}
+	err := buf.Flush()
+	return buf.Count(), err
+}
+
+func (t *Table) writeMarkdownDelimiter(buf *tableWriter, widths []int) {
+	buf.WriteString("|")
+	for i, width := range widths {
+		buf.WriteString(WS)
+		switch t.columns[i].BodyStyle.Align {
+		case ColumnAlignCenter:
+			buf.WriteString(":" + strings.Repeat("-", width-2) + ":")
+		case ColumnAlignRight, ColumnAlignDecimal:
+			buf.WriteString(strings.Repeat("-", width-1) + ":")
+		default:
+			buf.WriteString(":" + strings.Repeat("-", width-1))
+		}
+		buf.WriteString(" |")
+	}
+	buf.Write(EOL)
+}
@@ -0,0 +1,26 @@
+package clitable
+
+import "testing"
+
+func TestMarkdown(t *testing.T) {
+	WinSize.Col = 10
+	defer func() { WinSize.Col = 0 }()
+	table := NewTable("id", "name", "description")
+	table.GetColumnByName("id").BodyStyle = &ColumnStyle{Align: ColumnAlignRight}
+	table.GetColumnByName("name").BodyStyle = &ColumnStyle{Align: ColumnAlignCenter}
+	table.AddRow(1, "a|b", "first line\nsecond line")
+	table.AddRow(22, "\x1b[31mred\x1b[0m", "")
+
+	markdown :=
+		"|  id | name | description               |\n" +
+			"| --: | :--: | :------------------------ |\n" +
+			"|   1 | a\\|b | first line<br>second line |\n" +
+			"|  22 | red  |                           |\n"
+
+	markdownStr := table.Markdown()
+	t.Log(markdownStr)
+	t.Log(markdown)
+	if markdownStr != markdown {
+		t.Fail()
+	}
+}
@@ -0,0 +1,135 @@
+package clitable
+
+import (
+	"fmt"
+	"io"
+)
+
+type PageOptions struct {
+	// Size is the number of body rows per page. If zero, pages are filled
+	// up to the terminal height from WinSize.Row. A row span broken by a
+	// page repeats its value on the next page, wrapped into the rows there.
+	Size int
+	// Footer, if set, is printed under every page with the page number and
+	// the page count, e.g. "Page %d of %d".
+	Footer string
+	// FormFeed separates pages with a form feed instead of an empty line.
+	FormFeed bool
+}
+
+func (t *Table) PageCount(options *PageOptions) int {
+	return len(t.paginate(t.layout(), options))
+}
+
+// WritePage writes the header and the body rows of page, counted from 1.
+func (t *Table) WritePage(w io.Writer, page int, options *PageOptions) (int64, error) {
+	l := t.layout()
+	pages := t.paginate(l, options)
+	if page < 1 || page > len(pages) {
+		return 0, fmt.Errorf("clitable: page %d out of range 1..%d", page, len(pages))
+	}
+	buf := newTableWriter(w)
+	err := t.writePage(buf, l, pages, page, options)
+	return buf.Count(), err
+}
+
+// WritePages writes every page, each with its own header.
+func (t *Table) WritePages(w io.Writer, options *PageOptions) (int64, error) {
+	l := t.layout()
+	pages := t.paginate(l, options)
+	buf := newTableWriter(w)
+	for page := range pages {
+		if page > 0 {
+			if options != nil && options.FormFeed {
+				buf.WriteString("\f")
+			} else {
+				buf.Write(EOL)
+			}
+		}
+		if err := t.writePage(buf, l, pages, page+1, options); err != nil {
+			return buf.Count(), err
+		}
+	}
+	return buf.Count(), nil
+}
+
+func (t *Table) writePage(buf *tableWriter, l *layout, pages [][]*rowLayout, page int, options *PageOptions) error {
+	rows := append([]*rowLayout{l.rows[0]}, pages[page-1]...)
+	var footers []*rowLayout
+	if page == len(pages) {
+		footers = l.footers
+	}
+	if err := t.writeRows(buf, l, rows, footers); err != nil {
+		return err
+	}
+	if options != nil && options.Footer != "" {
+		buf.WriteString(fmt.Sprintf(options.Footer, page, len(pages)))
+		buf.Write(EOL)
+	}
+	return buf.Flush()
+}
+
+// paginate splits the body rows of l into pages. There is always at least
+// one page, which may be empty.
+func (t *Table) paginate(l *layout, options *PageOptions) [][]*rowLayout {
+	if options == nil {
+		options = new(PageOptions)
+	}
+	header, body := l.rows[0], l.rows[1:]
+	pages := make([][]*rowLayout, 0)
+	if options.Size > 0 {
+		for len(body) > options.Size {
+			pages = append(pages, body[:options.Size])
+			body = body[options.Size:]
+		}
+		return append(pages, body)
+	}
+
+	height := int(WinSize.Row)
+	if height <= 0 {
+		return append(pages, body)
+	}
+	fixed := header.height + t.lineCount(lineTop) + t.lineCount(lineBottom)
+	if options.Footer != "" {
+		fixed++
+	}
+	// The last page also holds the footer rows.
+	footerLines := 0
+	for _, rl := range l.footers {
+		footerLines += rl.height
+	}
+	if len(l.footers) > 0 {
+		footerLines += t.lineCount(lineFooter)
+	}
+	for len(body) > 0 {
+		lines, prev, n := fixed, header.row, 0
+		for n < len(body) {
+			rowLines := body[n].height
+			if _, ok := t.Style.separatorBefore(prev, body[n].row, n); ok {
+				rowLines++
+			}
+			if n == len(body)-1 {
+				rowLines += footerLines
+			}
+			if n > 0 && lines+rowLines > height {
+				break
+			}
+			lines += rowLines
+			prev = body[n].row
+			n++
+		}
+		pages = append(pages, body[:n])
+		body = body[n:]
+	}
+	if len(pages) == 0 {
+		pages = append(pages, body)
+	}
+	return pages
+}
+
+func (t *Table) lineCount(position linePosition) int {
+	if t.Style.showLine(position) {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,155 @@
+package clitable
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWritePage(t *testing.T) {
+	table := NewTable("id")
+	table.Style = &TableStyle{
+		VerticalBorder:   "|",
+		HorizontalBorder: "-",
+		Corner:           "+",
+		RowSeparators:    RowSeparatorsNone,
+	}
+	for i := 1; i <= 5; i++ {
+		table.AddRow(i)
+	}
+	options := &PageOptions{Size: 2, Footer: "Page %d of %d"}
+
+	if count := table.PageCount(options); count != 3 {
+		t.Errorf("count = %d", count)
+	}
+
+	page :=
+		"+--+\n" +
+			"|id|\n" +
+			"+--+\n" +
+			"|5 |\n" +
+			"+--+\n" +
+			"Page 3 of 3\n"
+
+	buf := new(bytes.Buffer)
+	if _, err := table.WritePage(buf, 3, options); err != nil {
+		t.Fatal(err)
+	}
+	t.Log(buf.String())
+	if buf.String() != page {
+		t.Fail()
+	}
+
+	if _, err := table.WritePage(buf, 4, options); err == nil {
+		t.Fail()
+	}
+}
+
+func TestWritePagesByTerminalHeight(t *testing.T) {
+	WinSize.Row = 6
+	defer func() { WinSize.Row = 0 }()
+	table := NewTable("id")
+	table.Style = &TableStyle{
+		VerticalBorder:   "|",
+		HorizontalBorder: "-",
+		Corner:           "+",
+		RowSeparators:    RowSeparatorsNone,
+	}
+	for i := 1; i <= 3; i++ {
+		table.AddRow(i)
+	}
+
+	pages :=
+		"+--+\n" +
+			"|id|\n" +
+			"+--+\n" +
+			"|1 |\n" +
+			"|2 |\n" +
+			"+--+\n" +
+			"\f" +
+			"+--+\n" +
+			"|id|\n" +
+			"+--+\n" +
+			"|3 |\n" +
+			"+--+\n"
+
+	buf := new(bytes.Buffer)
+	if _, err := table.WritePages(buf, &PageOptions{FormFeed: true}); err != nil {
+		t.Fatal(err)
+	}
+	t.Log(buf.String())
+	if buf.String() != pages {
+		t.Fail()
+	}
+}
+
+func TestRepeatHeader(t *testing.T) {
+	table := NewTable("id")
+	table.Style = &TableStyle{
+		VerticalBorder:   "|",
+		HorizontalBorder: "-",
+		Corner:           "+",
+		RowSeparators:    RowSeparatorsNone,
+	}
+	table.RepeatHeader = 2
+	for i := 1; i <= 3; i++ {
+		table.AddRow(i)
+	}
+
+	header :=
+		"+--+\n" +
+			"|id|\n" +
+			"+--+\n" +
+			"|1 |\n" +
+			"|2 |\n" +
+			"+--+\n" +
+			"|id|\n" +
+			"+--+\n" +
+			"|3 |\n" +
+			"+--+\n"
+
+	tableStr := table.String()
+	t.Log(tableStr)
+	t.Log(header)
+	if tableStr != header {
+		t.Fail()
+	}
+}
+
+func TestWritePagesWithFooter(t *testing.T) {
+	WinSize.Row = 7
+	defer func() { WinSize.Row = 0 }()
+	table := NewTable("id")
+	table.Style = &TableStyle{
+		VerticalBorder:   "|",
+		HorizontalBorder: "-",
+		Corner:           "+",
+		RowSeparators:    RowSeparatorsNone,
+	}
+	table.AddRow(1)
+	table.AddRow(2)
+	table.AddFooter("=")
+
+	pages :=
+		"+--+\n" +
+			"|id|\n" +
+			"+--+\n" +
+			"|1 |\n" +
+			"+--+\n" +
+			"\f" +
+			"+--+\n" +
+			"|id|\n" +
+			"+--+\n" +
+			"|2 |\n" +
+			"+--+\n" +
+			"|= |\n" +
+			"+--+\n"
+
+	buf := new(bytes.Buffer)
+	if _, err := table.WritePages(buf, &PageOptions{FormFeed: true}); err != nil {
+		t.Fatal(err)
+	}
+	t.Log(buf.String())
+	if buf.String() != pages {
+		t.Fail()
+	}
+}
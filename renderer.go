@@ -0,0 +1,89 @@
+package clitable
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+var ErrUnknownFormat = errors.New("clitable: unknown format")
+
+type Renderer interface {
+	Render(w io.Writer, view *TableView) error
+}
+
+type RendererFunc func(w io.Writer, view *TableView) error
+
+func (f RendererFunc) Render(w io.Writer, view *TableView) error {
+	return f(w, view)
+}
+
+var (
+	renderersMu sync.RWMutex
+	renderers   = map[string]Renderer{
+		"table": RendererFunc(func(w io.Writer, view *TableView) error {
+			_, err := view.table.WriteTo(w)
+			return err
+		}),
+		"markdown": RendererFunc(func(w io.Writer, view *TableView) error {
+			_, err := view.table.WriteMarkdown(w)
+			return err
+		}),
+		"csv": RendererFunc(func(w io.Writer, view *TableView) error {
+			_, err := view.table.WriteCSV(w, nil)
+			return err
+		}),
+		"tsv": RendererFunc(func(w io.Writer, view *TableView) error {
+			_, err := view.table.WriteTSV(w, nil)
+			return err
+		}),
+		"html": RendererFunc(func(w io.Writer, view *TableView) error {
+			_, err := view.table.WriteHTML(w)
+			return err
+		}),
+		"json": RendererFunc(func(w io.Writer, view *TableView) error {
+			_, err := view.table.WriteJSON(w)
+			return err
+		}),
+		"ndjson": RendererFunc(func(w io.Writer, view *TableView) error {
+			_, err := view.table.WriteNDJSON(w)
+			return err
+		}),
+	}
+)
+
+// RegisterRenderer makes a renderer available by name, replacing any
+// renderer already registered under that name.
+func RegisterRenderer(name string, renderer Renderer) {
+	renderersMu.Lock()
+	defer renderersMu.Unlock()
+	renderers[name] = renderer
+}
+
+func LookupRenderer(name string) (Renderer, bool) {
+	renderersMu.RLock()
+	defer renderersMu.RUnlock()
+	renderer, ok := renderers[name]
+	return renderer, ok
+}
+
+func RendererNames() []string {
+	renderersMu.RLock()
+	defer renderersMu.RUnlock()
+	names := make([]string, 0, len(renderers))
+	for name := range renderers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (t *Table) Render(w io.Writer, format string) error {
+	renderer, ok := LookupRenderer(format)
+	if !ok {
+		return fmt.Errorf("%w %q", ErrUnknownFormat, format)
+	}
+	return renderer.Render(w, t.View())
+}
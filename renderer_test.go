@@ -0,0 +1,51 @@
+package clitable
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+)
+
+func TestRenderByName(t *testing.T) {
+	table := NewTable("id", "name")
+	table.AddRow(1, "first")
+
+	buf := new(bytes.Buffer)
+	if err := table.Render(buf, "table"); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != table.String() {
+		t.Fail()
+	}
+
+	if err := table.Render(buf, "yaml"); !errors.Is(err, ErrUnknownFormat) {
+		t.Fail()
+	}
+}
+
+func TestRegisterRenderer(t *testing.T) {
+	RegisterRenderer("names", RendererFunc(func(w io.Writer, view *TableView) error {
+		for _, row := range view.Rows() {
+			for i := 0; i < row.Len(); i++ {
+				if _, err := fmt.Fprintf(w, "%s=%v\n", view.Column(i).Name(), row.Value(i)); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}))
+
+	table := NewTable("id", "name")
+	table.AddRow(1, "first")
+
+	buf := new(bytes.Buffer)
+	if err := table.Render(buf, "names"); err != nil {
+		t.Fatal(err)
+	}
+	t.Log(buf.String())
+	if buf.String() != "id=1\nname=first\n" {
+		t.Fail()
+	}
+}
@@ -1,14 +1,23 @@
 package clitable
 
 type Row struct {
-	height   int
-	cells    []*Cell
-	isHeader bool
+	cells        []*Cell
+	isHeader     bool
+	isFooter     bool
+	sectionBreak bool
+	Style        *TextStyle
 }
 
 func NewRow() *Row {
 	return &Row{
-		height: 1,
-		cells:  make([]*Cell, 0),
+		cells: make([]*Cell, 0),
+	}
+}
+
+func (r *Row) GetCellByNum(i int) *Cell {
+	if i >= 0 && i <= len(r.cells)-1 {
+		return r.cells[i]
+	} else {
+		return nil
 	}
 }
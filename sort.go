@@ -0,0 +1,168 @@
+package clitable
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+var ErrUnknownColumn = errors.New("clitable: unknown column")
+
+// Comparator returns a negative number if a sorts before b, a positive
+// number if it sorts after and zero if they are equal.
+type Comparator func(a, b interface{}) int
+
+type SortKey struct {
+	Column     string
+	Descending bool
+	// Natural compares strings with embedded numbers by value, so file2
+	// sorts before file10. Otherwise strings are compared bytewise.
+	Natural bool
+	// Compare, if set, replaces the default comparison of cell values.
+	Compare Comparator
+}
+
+func Asc(column string) SortKey {
+	return SortKey{Column: column}
+}
+
+func Desc(column string) SortKey {
+	return SortKey{Column: column, Descending: true}
+}
+
+// SortBy stably sorts the body rows by keys, in order of priority. The
+// header row stays first. Numbers compare as numbers and times
+// chronologically; blank cells sort last in either direction.
+func (t *Table) SortBy(keys ...SortKey) error {
+	indexes := make([]int, len(keys))
+	for k, key := range keys {
+		index, ok := t.getColumnIndex(key.Column)
+		if !ok {
+			return fmt.Errorf("%w %q", ErrUnknownColumn, key.Column)
+		}
+		indexes[k] = index
+	}
+	body := t.rows[1:]
+	sort.SliceStable(body, func(i, j int) bool {
+		for k, key := range keys {
+			a, b := body[i].cells[indexes[k]].spanned(), body[j].cells[indexes[k]].spanned()
+			if blankA, blankB := isBlank(a.value), isBlank(b.value); blankA != blankB {
+				return blankB
+			}
+			var result int
+			if key.Compare != nil {
+				result = key.Compare(a.value, b.value)
+			} else {
+				result = compareCells(a, b, key.Natural)
+			}
+			if key.Descending {
+				result = -result
+			}
+			if result != 0 {
+				return result < 0
+			}
+		}
+		return false
+	})
+	return nil
+}
+
+func (t *Table) getColumnIndex(name string) (int, bool) {
+	column, ok := t.columnsMap[name]
+	if !ok {
+		return 0, false
+	}
+	for i, c := range t.columns {
+		if c == column {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+const (
+	rankNumber = iota
+	rankTime
+	rankString
+	rankBlank
+)
+
+func valueRank(value interface{}) int {
+	if isBlank(value) {
+		return rankBlank
+	}
+	if _, ok := toFloat(value); ok {
+		return rankNumber
+	}
+	if _, ok := value.(time.Time); ok {
+		return rankTime
+	}
+	return rankString
+}
+
+func compareCells(a, b *Cell, natural bool) int {
+	rankA, rankB := valueRank(a.value), valueRank(b.value)
+	if rankA != rankB {
+		return rankA - rankB
+	}
+	switch rankA {
+	case rankNumber:
+		x, _ := toFloat(a.value)
+		y, _ := toFloat(b.value)
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	case rankTime:
+		x, y := a.value.(time.Time), b.value.(time.Time)
+		switch {
+		case x.Before(y):
+			return -1
+		case x.After(y):
+			return 1
+		}
+		return 0
+	case rankString:
+		if natural {
+			return compareNatural(a.data, b.data)
+		}
+		return strings.Compare(a.data, b.data)
+	}
+	return 0
+}
+
+// compareNatural compares strings treating runs of digits as numbers.
+func compareNatural(a, b string) int {
+	for a != "" && b != "" {
+		if isDigit(a[0]) && isDigit(b[0]) {
+			i, j := digitsLen(a), digitsLen(b)
+			x, y := strings.TrimLeft(a[:i], "0"), strings.TrimLeft(b[:j], "0")
+			if len(x) != len(y) {
+				return len(x) - len(y)
+			}
+			if result := strings.Compare(x, y); result != 0 {
+				return result
+			}
+			a, b = a[i:], b[j:]
+			continue
+		}
+		if a[0] != b[0] {
+			return int(a[0]) - int(b[0])
+		}
+		a, b = a[1:], b[1:]
+	}
+	return len(a) - len(b)
+}
+
+func digitsLen(s string) int {
+	i := 0
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	return i
+}
@@ -0,0 +1,73 @@
+package clitable
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCompareNatural(t *testing.T) {
+	if compareNatural("file2", "file10") >= 0 {
+		t.Fail()
+	}
+	if compareNatural("file010", "file9") <= 0 {
+		t.Fail()
+	}
+	if compareNatural("a", "a") != 0 {
+		t.Fail()
+	}
+}
+
+func TestSortBy(t *testing.T) {
+	table := NewTable("region", "name", "cpu")
+	table.AddRow("eu", "file10", 2.5)
+	table.AddRow("us", "file2", 10)
+	table.AddRow("eu", "file2", 9)
+	table.AddRow("us", "file1")
+
+	if err := table.SortBy(Asc("region"), Desc("cpu")); err != nil {
+		t.Fatal(err)
+	}
+	names := make([]string, 0)
+	for _, row := range table.rows {
+		names = append(names, row.cells[1].data)
+	}
+	if strings.Join(names, " ") != "name file2 file10 file2 file1" {
+		t.Error(names)
+	}
+
+	if err := table.SortBy(SortKey{Column: "name", Natural: true}); err != nil {
+		t.Fatal(err)
+	}
+	names = names[:0]
+	for _, row := range table.rows {
+		names = append(names, row.cells[1].data)
+	}
+	if strings.Join(names, " ") != "name file1 file2 file2 file10" {
+		t.Error(names)
+	}
+
+	if err := table.SortBy(Asc("missing")); !errors.Is(err, ErrUnknownColumn) {
+		t.Error(err)
+	}
+}
+
+func TestSortByTimeAndComparator(t *testing.T) {
+	table := NewTable("at", "label")
+	now := time.Now()
+	table.AddRow(now, "later")
+	table.AddRow(now.Add(-time.Hour), "earlier")
+
+	table.SortBy(Asc("at"))
+	if table.rows[1].cells[1].data != "earlier" {
+		t.Fail()
+	}
+
+	table.SortBy(SortKey{Column: "label", Compare: func(a, b interface{}) int {
+		return len(a.(string)) - len(b.(string))
+	}})
+	if table.rows[1].cells[1].data != "later" {
+		t.Fail()
+	}
+}
@@ -0,0 +1,176 @@
+package clitable
+
+// Span is a cell value that covers Columns adjacent columns and Rows
+// consecutive body rows, starting at the cell it is added in. Rows added
+// later skip the covered columns when taking their values. Spans are drawn
+// from the current row order: when sorting, filtering, a page break or a
+// repeated header separates covered rows from the row above them, they
+// start a new span with the same value.
+type Span struct {
+	Value   interface{}
+	Columns int
+	Rows    int
+}
+
+func ColSpan(columns int, value interface{}) Span {
+	return Span{Value: value, Columns: columns}
+}
+
+func RowSpan(rows int, value interface{}) Span {
+	return Span{Value: value, Rows: rows}
+}
+
+type rowSpan struct {
+	origin *Cell
+	// rows is the number of rows the span has yet to cover.
+	rows int
+}
+
+func (t *Table) startRowSpan(column int, origin *Cell, rows int) {
+	if t.rowSpans == nil {
+		t.rowSpans = make(map[int]*rowSpan)
+	}
+	t.rowSpans[column] = &rowSpan{origin: origin, rows: rows - 1}
+}
+
+// continueRowSpan adds the cells of span to row, starting at column.
+func (t *Table) continueRowSpan(row *Row, column int, span *rowSpan) {
+	for j := 0; j < span.origin.colspan; j++ {
+		cell := NewCell("")
+		cell.colspan = span.origin.colspan
+		cell.covered = j > 0
+		cell.missing = true
+		cell.origin = span.origin
+		row.cells = append(row.cells, cell)
+	}
+	if span.rows--; span.rows == 0 {
+		delete(t.rowSpans, column)
+	}
+}
+
+// continuesSpan reports whether the cell in column i of row continues the
+// row span of the cell above it in the row order being drawn.
+func continuesSpan(above, row *Row, i int) bool {
+	origin := row.cells[i].origin
+	if origin == nil || above == nil {
+		return false
+	}
+	return above.cells[i] == origin || above.cells[i].origin == origin
+}
+
+// freeColumns returns the number of columns from column up to the next one
+// covered by a row span.
+func (t *Table) freeColumns(column int) int {
+	free := len(t.columns) - column
+	for start := range t.rowSpans {
+		if start >= column && start-column < free {
+			free = start - column
+		}
+	}
+	return free
+}
+
+// spanLines tracks the row-spanning cells while rows are written. heads
+// maps every cell to the first cell of its span, heights holds the
+// combined height of each span, separator lines included, and drawn the
+// number of its lines written so far.
+type spanLines struct {
+	heads   map[*cellLayout]*cellLayout
+	heights map[*cellLayout]int
+	drawn   map[*cellLayout]int
+}
+
+func (t *Table) spanLines(rows []*rowLayout, lines []bool) *spanLines {
+	s := &spanLines{
+		heads:   make(map[*cellLayout]*cellLayout),
+		heights: make(map[*cellLayout]int),
+		drawn:   make(map[*cellLayout]int),
+	}
+	spanned := make(map[*cellLayout]bool)
+	for y, rl := range rows {
+		for i, cl := range rl.cells {
+			if cl.span == 0 {
+				continue
+			}
+			head := cl
+			if y > 0 && continuesSpan(rows[y-1].row, rl.row, i) {
+				head = s.heads[rows[y-1].cells[i]]
+				if lines[y] {
+					s.heights[head]++
+				}
+			}
+			s.heads[cl] = head
+			s.heights[head] += rl.height
+			if head != cl {
+				spanned[head] = true
+			}
+		}
+	}
+	// Cells outside row spans, such as a repeated header, are left out.
+	for cl, head := range s.heads {
+		if !spanned[head] {
+			delete(s.heads, cl)
+			delete(s.heights, cl)
+		}
+	}
+	return s
+}
+
+// resolve returns the cell whose lines are written in place of cl, the
+// first line to write and the height to align it in.
+func (s *spanLines) resolve(cl *cellLayout, height int) (*cellLayout, int, int) {
+	head, ok := s.heads[cl]
+	if !ok {
+		return cl, 0, height
+	}
+	return head, s.drawn[head], s.heights[head]
+}
+
+// inside reports whether the line between above and below crosses a
+// row-spanning cell in column i.
+func (s *spanLines) inside(above, below *rowLayout, i int) bool {
+	if above == nil || below == nil {
+		return false
+	}
+	a, b := spanStart(above, i), spanStart(below, i)
+	head, ok := s.heads[below.cells[b]]
+	return a == b && ok && head != below.cells[b] && s.heads[above.cells[a]] == head
+}
+
+// spanStart returns the first column of the cell covering column i.
+func spanStart(rl *rowLayout, i int) int {
+	for i > 0 && rl.cells[i].span == 0 {
+		i--
+	}
+	return i
+}
+
+func (l *layout) spanWidth(i, span int) int {
+	width := (span - 1) * l.separatorWidth
+	for _, w := range l.widths[i : i+span] {
+		width += w
+	}
+	return width
+}
+
+// widenSpans grows the columns under a spanning cell evenly when the cell
+// does not fit into them.
+func (t *Table) widenSpans(l *layout, rows []*rowLayout) {
+	for _, rl := range rows {
+		for i, cl := range rl.cells {
+			if cl.span < 2 {
+				continue
+			}
+			style := t.columns[i].getStyleByRow(rl.row)
+			extra := cl.widths[0] + style.PaddingLeft + style.PaddingRight - l.spanWidth(i, cl.span)
+			for k := 0; k < cl.span && extra > 0; k++ {
+				grow := extra / (cl.span - k)
+				if extra%(cl.span-k) > 0 {
+					grow++
+				}
+				l.widths[i+k] += grow
+				extra -= grow
+			}
+		}
+	}
+}
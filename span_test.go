@@ -0,0 +1,241 @@
+package clitable
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestColSpan(t *testing.T) {
+	table := NewTable("id", "name", "status")
+	table.Style = TableStyleSingle()
+	table.AddRow(1, "first", "ok")
+	table.AddRow(ColSpan(3, "a note that is long"))
+	table.AddRow(2, ColSpan(2, "second"))
+
+	header :=
+		"┌────┬──────┬───────┐\n" +
+			"│ id │ name │status │\n" +
+			"├────┼──────┼───────┤\n" +
+			"│1   │first │ok     │\n" +
+			"├────┴──────┴───────┤\n" +
+			"│a note that is long│\n" +
+			"├────┬──────────────┤\n" +
+			"│2   │second        │\n" +
+			"└────┴──────────────┘\n"
+	tableStr := table.String()
+	t.Log("\n" + tableStr)
+	if tableStr != header {
+		t.Fail()
+	}
+}
+
+func TestColSpanHTML(t *testing.T) {
+	table := NewTable("id", "name")
+	table.AddRow(ColSpan(2, "note"))
+
+	html := table.HTML()
+	t.Log(html)
+	if !strings.Contains(html, "<td colspan=\"2\" style=") || strings.Count(html, "<td") != 1 {
+		t.Fail()
+	}
+}
+
+func TestRowSpan(t *testing.T) {
+	WinSize.Col = 30
+	defer func() { WinSize.Col = 0 }()
+	table := NewTable("group", "name", "status")
+	table.Style = TableStyleSingle()
+	table.GetColumnByName("group").BodyStyle = &ColumnStyle{VerticalAlign: ColumnVerticalAlignMiddle}
+	table.AddRow(RowSpan(3, "web"), "web-1", "ok")
+	table.AddRow("web-2", "ok")
+	table.AddRow("web-3", "down")
+	table.AddRow("db", Span{Value: "one two three four five six seven", Columns: 2, Rows: 2})
+	table.AddRow("db-2")
+	table.AddRow("x", "y", "z")
+
+	header :=
+		"┌─────┬───────┬──────────┐\n" +
+			"│group│ name  │  status  │\n" +
+			"├─────┼───────┼──────────┤\n" +
+			"│     │web-1  │ok        │\n" +
+			"│     ├───────┼──────────┤\n" +
+			"│web  │web-2  │ok        │\n" +
+			"│     ├───────┼──────────┤\n" +
+			"│     │web-3  │down      │\n" +
+			"├─────┼───────┴──────────┤\n" +
+			"│db   │one two three     │\n" +
+			"├─────┤four five six     │\n" +
+			"│db-2 │seven             │\n" +
+			"├─────┼───────┬──────────┤\n" +
+			"│x    │y      │z         │\n" +
+			"└─────┴───────┴──────────┘\n"
+
+	tableStr := table.String()
+	t.Log("\n" + tableStr)
+	if tableStr != header {
+		t.Fail()
+	}
+
+	html := table.HTML()
+	t.Log(html)
+	if !strings.Contains(html, "<td rowspan=\"3\" style=") ||
+		!strings.Contains(html, "<td colspan=\"2\" rowspan=\"2\" style=") ||
+		strings.Count(html, "<td") != 13 {
+		t.Fail()
+	}
+}
+
+func newRowSpanTable() *Table {
+	table := NewTable("group", "name")
+	table.AddRow(RowSpan(2, "web"), "b")
+	table.AddRow("a")
+	table.AddRow("db", "c")
+	return table
+}
+
+func TestRowSpanSort(t *testing.T) {
+	table := newRowSpanTable()
+	table.SortBy(Asc("name"))
+
+	header :=
+		"+-----+----+\n" +
+			"|group|name|\n" +
+			"+-----+----+\n" +
+			"|web  |a   |\n" +
+			"+-----+----+\n" +
+			"|web  |b   |\n" +
+			"+-----+----+\n" +
+			"|db   |c   |\n" +
+			"+-----+----+\n"
+
+	tableStr := table.String()
+	t.Log(tableStr)
+	t.Log(header)
+	if tableStr != header {
+		t.Fail()
+	}
+
+	html := table.HTML()
+	t.Log(html)
+	if strings.Contains(html, "rowspan") || strings.Count(html, "<td") != 6 {
+		t.Fail()
+	}
+}
+
+func TestRowSpanFilter(t *testing.T) {
+	table := newRowSpanTable()
+
+	header :=
+		"+-----+----+\n" +
+			"|group|name|\n" +
+			"+-----+----+\n" +
+			"|web  |a   |\n" +
+			"+-----+----+\n"
+
+	filtered := table.Filter(Contains("name", "a"))
+	tableStr := filtered.String()
+	t.Log(tableStr)
+	t.Log(header)
+	if tableStr != header {
+		t.Fail()
+	}
+
+	for _, name := range []string{"a", "b"} {
+		html := table.Filter(Contains("name", name)).HTML()
+		t.Log(html)
+		if strings.Contains(html, "rowspan") || !strings.Contains(html, ">web</td>") || strings.Count(html, "<td") != 2 {
+			t.Fail()
+		}
+	}
+
+	filtered = table.Filter(Contains("name", "b"))
+	filtered.AddRow(RowSpan(3, "x"), "y")
+	table.AddRow("p", "q")
+	if data := table.View().Rows()[3].String(0); data != "p" {
+		t.Errorf("row span of the filtered table covers %q", data)
+	}
+}
+
+func TestRowSpanValues(t *testing.T) {
+	table := NewTable("region", "name", "count")
+	origin := table.AddRow(RowSpan(2, "eu"), "a", 2).GetCellByNum(0)
+	table.AddRow("b", 1)
+	table.AddRow("us", "c", 3)
+	table.SortBy(Desc("region"))
+
+	header :=
+		"+------+----+-----+\n" +
+			"|region|name|count|\n" +
+			"+------+----+-----+\n" +
+			"|us    |c   |3    |\n" +
+			"+------+----+-----+\n" +
+			"|eu    |a   |2    |\n" +
+			"|      +----+-----+\n" +
+			"|      |b   |1    |\n" +
+			"+------+----+-----+\n"
+
+	tableStr := table.String()
+	t.Log(tableStr)
+	t.Log(header)
+	if tableStr != header {
+		t.Fail()
+	}
+
+	row := table.Filter(Contains("region", "eu")).View().Rows()[1]
+	if value, _ := row.ValueByName("region"); value != "eu" {
+		t.Errorf("region = %v", value)
+	}
+	if data, _ := row.StringByName("name"); data != "b" {
+		t.Errorf("name = %q", data)
+	}
+	origin.Style = &TextStyle{Bold: true}
+	if !table.View().Rows()[2].TextStyle(0).Bold {
+		t.Error("spanned cell lost the style of its row span")
+	}
+
+	buf := new(bytes.Buffer)
+	table.WriteCSV(buf, &CSVOptions{SkipHeader: true})
+	if buf.String() != "us,c,3\neu,a,2\neu,b,1\n" {
+		t.Error(buf.String())
+	}
+
+	buf.Reset()
+	table.WriteJSON(buf)
+	if !strings.Contains(buf.String(), `{"region":"eu","name":"b","count":1}`) {
+		t.Error(buf.String())
+	}
+
+	markdown := table.Markdown()
+	if !strings.Contains(markdown, "| eu     | b    | 1     |") {
+		t.Error(markdown)
+	}
+}
+
+func TestRowSpanRepeatHeader(t *testing.T) {
+	table := NewTable("group", "name")
+	table.RepeatHeader = 2
+	table.AddRow(RowSpan(3, "web"), "a")
+	table.AddRow("b")
+	table.AddRow("c")
+
+	header :=
+		"+-----+----+\n" +
+			"|group|name|\n" +
+			"+-----+----+\n" +
+			"|web  |a   |\n" +
+			"|     +----+\n" +
+			"|     |b   |\n" +
+			"+-----+----+\n" +
+			"|group|name|\n" +
+			"+-----+----+\n" +
+			"|web  |c   |\n" +
+			"+-----+----+\n"
+
+	tableStr := table.String()
+	t.Log(tableStr)
+	t.Log(header)
+	if tableStr != header {
+		t.Fail()
+	}
+}
@@ -0,0 +1,175 @@
+package clitable
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var ErrUnsupportedType = errors.New("clitable: unsupported type")
+
+var columnAligns = map[string]ColumnAlign{
+	"left":    ColumnAlignLeft,
+	"center":  ColumnAlignCenter,
+	"right":   ColumnAlignRight,
+	"decimal": ColumnAlignDecimal,
+}
+
+type structField struct {
+	index    []int
+	header   string
+	align    ColumnAlign
+	hasAlign bool
+	order    int
+	hasOrder bool
+}
+
+// NewTableFromStructs builds a table from a slice or array of structs or
+// struct pointers. Every exported field becomes a column; fields of
+// embedded structs are promoted. The clitable tag sets the header and
+// options, e.g. `clitable:"Name,align=right,order=1"`; "-" or the omit
+// option skips the field. Fields with an order come first, sorted by it,
+// followed by the others in declaration order. Nil pointers give empty
+// cells.
+func NewTableFromStructs(rows interface{}) (*Table, error) {
+	value := reflect.ValueOf(rows)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return nil, fmt.Errorf("%w %T: expected a slice of structs", ErrUnsupportedType, rows)
+	}
+	elemType := value.Type().Elem()
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w %T: expected a slice of structs", ErrUnsupportedType, rows)
+	}
+
+	fields, err := structFields(elemType, nil, make(map[reflect.Type]bool))
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(fields, func(i, j int) bool {
+		if fields[i].hasOrder != fields[j].hasOrder {
+			return fields[i].hasOrder
+		}
+		return fields[i].order < fields[j].order
+	})
+
+	names := make([]interface{}, len(fields))
+	for i, field := range fields {
+		names[i] = field.header
+	}
+	table := NewTable(names...)
+	for i, field := range fields {
+		if field.hasAlign {
+			style := *table.columns[i].BodyStyle
+			style.Align = field.align
+			table.columns[i].BodyStyle = &style
+		}
+	}
+
+	datas := make([]interface{}, len(fields))
+	for y := 0; y < value.Len(); y++ {
+		for i, field := range fields {
+			datas[i] = structFieldValue(value.Index(y), field.index)
+		}
+		table.AddRow(datas...)
+	}
+	return table, nil
+}
+
+// structFields lists the columns of typ. visited holds the struct types
+// being expanded, so that a type embedding itself is not expanded again.
+func structFields(typ reflect.Type, index []int, visited map[reflect.Type]bool) ([]*structField, error) {
+	visited[typ] = true
+	defer delete(visited, typ)
+	fields := make([]*structField, 0, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		fieldIndex := append(append([]int{}, index...), i)
+		tag, hasTag := field.Tag.Lookup("clitable")
+		if tag == "-" {
+			continue
+		}
+		if field.Anonymous && !hasTag {
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if visited[fieldType] {
+				continue
+			}
+			if fieldType.Kind() == reflect.Struct {
+				embedded, err := structFields(fieldType, fieldIndex, visited)
+				if err != nil {
+					return nil, err
+				}
+				fields = append(fields, embedded...)
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+
+		sf := &structField{
+			index:  fieldIndex,
+			header: field.Name,
+		}
+		options := strings.Split(tag, ",")
+		if options[0] != "" {
+			sf.header = options[0]
+		}
+		omit := false
+		for _, option := range options[1:] {
+			key, val := option, ""
+			if i := strings.Index(option, "="); i >= 0 {
+				key, val = option[:i], option[i+1:]
+			}
+			switch key {
+			case "omit":
+				omit = true
+			case "align":
+				align, ok := columnAligns[val]
+				if !ok {
+					return nil, fmt.Errorf("clitable: field %s: unknown align %q", field.Name, val)
+				}
+				sf.align, sf.hasAlign = align, true
+			case "order":
+				order, err := strconv.Atoi(val)
+				if err != nil {
+					return nil, fmt.Errorf("clitable: field %s: invalid order %q", field.Name, val)
+				}
+				sf.order, sf.hasOrder = order, true
+			default:
+				return nil, fmt.Errorf("clitable: field %s: unknown tag option %q", field.Name, option)
+			}
+		}
+		if !omit {
+			fields = append(fields, sf)
+		}
+	}
+	return fields, nil
+}
+
+func structFieldValue(value reflect.Value, index []int) interface{} {
+	for _, i := range index {
+		for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+			if value.IsNil() {
+				return missingValue{}
+			}
+			value = value.Elem()
+		}
+		value = value.Field(i)
+	}
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return missingValue{}
+		}
+		value = value.Elem()
+	}
+	return value.Interface()
+}
@@ -0,0 +1,89 @@
+package clitable
+
+import (
+	"errors"
+	"testing"
+)
+
+type testAudit struct {
+	Created string `clitable:"Created At"`
+}
+
+type testServer struct {
+	*testAudit
+	ID       int     `clitable:"#,align=right,order=1"`
+	Name     string  `clitable:",order=2"`
+	Region   *string `clitable:"Region"`
+	Password string  `clitable:"-"`
+	Internal bool    `clitable:",omit"`
+	hidden   int
+}
+
+func TestNewTableFromStructs(t *testing.T) {
+	region := "eu"
+	servers := []*testServer{
+		{testAudit: &testAudit{Created: "2020"}, ID: 1, Name: "web", Region: &region},
+		{ID: 22, Name: "db"},
+	}
+	table, err := NewTableFromStructs(servers)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	header :=
+		"+--+----+----------+------+\n" +
+			"|# |Name|Created At|Region|\n" +
+			"+--+----+----------+------+\n" +
+			"| 1|web |2020      |eu    |\n" +
+			"+--+----+----------+------+\n" +
+			"|22|db  |          |      |\n" +
+			"+--+----+----------+------+\n"
+
+	tableStr := table.String()
+	t.Log(tableStr)
+	t.Log(header)
+	if tableStr != header {
+		t.Fail()
+	}
+}
+
+func TestNewTableFromStructsErrors(t *testing.T) {
+	if _, err := NewTableFromStructs([]int{1}); !errors.Is(err, ErrUnsupportedType) {
+		t.Error(err)
+	}
+	if _, err := NewTableFromStructs(testServer{}); !errors.Is(err, ErrUnsupportedType) {
+		t.Error(err)
+	}
+	type badTag struct {
+		ID int `clitable:",align=middle"`
+	}
+	if _, err := NewTableFromStructs([]badTag{}); err == nil {
+		t.Fail()
+	}
+}
+
+type testNode struct {
+	*testNode
+	Name string
+}
+
+func TestNewTableFromStructsRecursive(t *testing.T) {
+	table, err := NewTableFromStructs([]testNode{{Name: "root"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	header :=
+		"+----+\n" +
+			"|Name|\n" +
+			"+----+\n" +
+			"|root|\n" +
+			"+----+\n"
+
+	tableStr := table.String()
+	t.Log(tableStr)
+	t.Log(header)
+	if tableStr != header {
+		t.Fail()
+	}
+}
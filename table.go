@@ -2,36 +2,32 @@ package clitable
 
 import (
 	"bytes"
-	"fmt"
-	"math"
+	"io"
+	"os"
 	"strings"
-	"unicode/utf8"
 )
 
-var (
-	defaultTableStyle = &TableStyle{
-		VerticalBorder:   "|",
-		HorizontalBorder: "-",
-		Corner:           "+",
-	}
-)
-
-type TableStyle struct {
-	VerticalBorder   string
-	HorizontalBorder string
-	Corner           string
-}
+const RepeatHeaderPerScreen = -1
 
 type Table struct {
-	columns    []*Column
-	columnsMap map[string]*Column
-	Style      *TableStyle
-	rows       []*Row
+	columns      []*Column
+	columnsMap   map[string]*Column
+	Style        *TableStyle
+	ColorProfile ColorProfile
+	Caption      string
+	// RepeatHeader prints the header again after every RepeatHeader body
+	// rows, or after every screenful with RepeatHeaderPerScreen. A row span
+	// broken by the header repeats its value below it.
+	RepeatHeader int
+	rows         []*Row
+	footers      []*Row
+	sectionBreak bool
+	rowSpans     map[int]*rowSpan
 }
 
 func NewTable(names ...interface{}) *Table {
 	table := &Table{
-		Style:      defaultTableStyle,
+		Style:      TableStyleASCII(),
 		columns:    make([]*Column, len(names)),
 		columnsMap: make(map[string]*Column),
 		rows:       make([]*Row, 0),
@@ -52,212 +48,354 @@ func (t *Table) addHeader(datas ...interface{}) {
 	t.addRow(row, datas...)
 }
 
-func (t *Table) AddRow(datas ...interface{}) {
+func (t *Table) AddRow(datas ...interface{}) *Row {
 	row := NewRow()
 	t.addRow(row, datas...)
+	return row
+}
+
+// AddFooter adds a row printed after the body. Aggregate values are
+// computed from the body rows at render time; other values are printed as
+// they are.
+func (t *Table) AddFooter(datas ...interface{}) *Row {
+	row := NewRow()
+	row.isFooter = true
+	t.addCells(row, datas...)
+	t.footers = append(t.footers, row)
+	return row
+}
+
+func (t *Table) AddSeparator() {
+	t.sectionBreak = true
 }
 
 func (t *Table) addRow(row *Row, datas ...interface{}) {
+	t.addCells(row, datas...)
+	if !row.isHeader {
+		row.sectionBreak = t.sectionBreak
+		t.sectionBreak = false
+	}
+	t.rows = append(t.rows, row)
+}
+
+func (t *Table) addCells(row *Row, datas ...interface{}) {
 	var data interface{}
 	datasLen := len(datas)
-	for i, _ := range t.columns {
+	isBody := !row.isHeader && !row.isFooter
+	for i := 0; len(row.cells) < len(t.columns); {
+		if span, ok := t.rowSpans[len(row.cells)]; ok && isBody {
+			t.continueRowSpan(row, len(row.cells), span)
+			continue
+		}
 		if i >= 0 && i < datasLen {
 			data = datas[i]
 		} else {
+			data = missingValue{}
+		}
+		i++
+		colspan, rowspan := 1, 1
+		if span, ok := data.(Span); ok {
+			data = span.Value
+			if span.Columns > 1 {
+				colspan = span.Columns
+			}
+			if rest := t.freeColumns(len(row.cells)); colspan > rest {
+				colspan = rest
+			}
+			if span.Rows > 1 && isBody {
+				rowspan = span.Rows
+			}
+		}
+		_, missing := data.(missingValue)
+		if missing {
 			data = ""
 		}
 		cell := NewCell(data)
+		cell.colspan = colspan
+		cell.missing = missing
+		if rowspan > 1 {
+			t.startRowSpan(len(row.cells), cell, rowspan)
+		}
 		row.cells = append(row.cells, cell)
+		for j := 1; j < colspan; j++ {
+			covered := NewCell("")
+			covered.covered = true
+			covered.missing = true
+			row.cells = append(row.cells, covered)
+		}
 	}
-	t.rows = append(t.rows, row)
 }
 
-func (t *Table) getVerticalBorderWidth() int {
-	return utf8.RuneCountInString(t.Style.VerticalBorder)
+func (t *Table) getCellValue(row *Row, i int) interface{} {
+	return t.cellValue(row.cells[i], i)
+}
+
+func (t *Table) cellValue(cell *Cell, i int) interface{} {
+	cell = cell.spanned()
+	if aggregate, ok := cell.value.(Aggregate); ok {
+		return aggregate(t.getColumnValues(i))
+	}
+	return cell.value
+}
+
+func (t *Table) getCellData(row *Row, i int) string {
+	return t.cellData(row.cells[i], i, row.isHeader)
+}
+
+func (t *Table) cellData(cell *Cell, i int, isHeader bool) string {
+	cell = cell.spanned()
+	if isHeader {
+		return cell.data
+	}
+	value := t.cellValue(cell, i)
+	if _, ok := cell.value.(Aggregate); ok {
+		return t.aggregateData(i, value)
+	}
+	format := t.columns[i].Format
+	if format == nil || isBlank(value) {
+		return cell.data
+	}
+	return format(value)
+}
+
+func (t *Table) getColorProfile() ColorProfile {
+	if t.ColorProfile == ColorProfileAuto {
+		return DefaultColorProfile
+	}
+	return t.ColorProfile
+}
+
+func (t *Table) getBorderSGR() string {
+	if t.Style.BorderStyle == nil {
+		return ""
+	}
+	return t.Style.BorderStyle.sgr(t.getColorProfile())
 }
 
 func (t *Table) Print() {
-	fmt.Print(t.String())
+	t.Fprint(os.Stdout)
+}
+
+func (t *Table) Fprint(w io.Writer) error {
+	_, err := t.WriteTo(w)
+	return err
 }
 
 func (t *Table) String() string {
-	cornerWidth := utf8.RuneCountInString(t.Style.Corner)
-	verticalBorderWidth := t.getVerticalBorderWidth()
-
-	for _, row := range t.rows {
-		for i, cell := range row.cells {
-			column := t.columns[i]
-			style := column.getStyleByRow(row)
-			columnWidth := cell.width + style.PaddingLeft + style.PaddingRight
-			if column.width < columnWidth {
-				column.width = columnWidth
-			}
+	buf := new(bytes.Buffer)
+	t.WriteTo(buf)
+	return buf.String()
+}
+
+func (t *Table) WriteTo(w io.Writer) (int64, error) {
+	l := t.layout()
+	rows := l.rows
+	if t.RepeatHeader != 0 {
+		options := &PageOptions{Size: t.RepeatHeader}
+		if t.RepeatHeader == RepeatHeaderPerScreen {
+			options.Size = 0
+		}
+		rows = make([]*rowLayout, 0, len(l.rows))
+		for _, page := range t.paginate(l, options) {
+			rows = append(rows, l.rows[0])
+			rows = append(rows, page...)
 		}
 	}
+	buf := newTableWriter(w)
+	err := t.writeRows(buf, l, rows, l.footers)
+	return buf.Count(), err
+}
 
-	maxRowWidth := 0
-	for _, column := range t.columns {
-		maxRowWidth += column.width
+// separators returns the line drawn before each of rows, and whether it
+// is drawn at all.
+func (t *Table) separators(rows []*rowLayout) ([]linePosition, []bool) {
+	positions := make([]linePosition, len(rows))
+	lines := make([]bool, len(rows))
+	var prevRow *Row
+	bodyIndex := 0
+	for y, rl := range rows {
+		positions[y], lines[y] = t.Style.separatorBefore(prevRow, rl.row, bodyIndex)
+		if !rl.row.isHeader {
+			bodyIndex++
+		}
+		prevRow = rl.row
 	}
+	return positions, lines
+}
 
-	fullRowWidth := maxRowWidth + verticalBorderWidth*(len(t.columns)+1)
-	winCol := int(WinSize.Col)
-
-	if fullRowWidth > winCol && winCol > 0 {
-		excess := float64(fullRowWidth-winCol) + 5
-		maxExcess := excess
-		columnsCount := len(t.columns)
-		meanColumnWidth := float64(maxRowWidth) / float64(columnsCount)
-		maxWidth := 0
-		maxWidthColumn := 0
-		var currentRate float64
-		for i, column := range t.columns {
-			rate := (100 * float64(column.width)) / float64(maxRowWidth)
-			currentRate += rate
-			if float64(column.width)+maxExcess-excess > meanColumnWidth {
-				excessColumn := excess * currentRate / 100
-				column.width -= int(math.Floor(excessColumn))
-				excess -= excessColumn
-			}
-			if maxWidth < column.width {
-				maxWidth = column.width
-				maxWidthColumn = i
-			}
+func (t *Table) writeRows(buf *tableWriter, l *layout, rows, footers []*rowLayout) error {
+	positions, lines := t.separators(rows)
+	spans := t.spanLines(rows, lines)
+
+	var prev *rowLayout
+	for y, rl := range rows {
+		if lines[y] {
+			t.writeLine(buf, l, spans, positions[y], prev, rl)
 		}
-		if excess > 0 {
-			t.columns[maxWidthColumn].width -= int(math.Floor(excess))
+		prev = rl
+		t.writeRow(buf, l, spans, rl)
+		if err := buf.Flush(); err != nil {
+			return err
 		}
-		for _, row := range t.rows {
-			for i, cell := range row.cells {
-				column := t.columns[i]
-				style := column.getStyleByRow(row)
-				if cell.width > column.width {
-					columnWidth := column.width - (style.PaddingLeft + style.PaddingRight)
-					srcParts := strings.Split(cell.data, WS)
-					srcPartsLen := len(srcParts)
-					lastStrPart := srcPartsLen - 1
-					dstParts := make([]string, 0)
-					cellBuf := new(bytes.Buffer)
-					for j := 0; j < srcPartsLen; j++ {
-						srcPart := srcParts[j]
-						srcPartLen := utf8.RuneCountInString(srcPart)
-						if srcPartLen > columnWidth {
-							dstParts = append(dstParts, srcPart[0:column.width-1])
-						} else {
-							cellBufNextLen := utf8.RuneCount(cellBuf.Bytes()) + srcPartLen
-							if cellBufNextLen < columnWidth {
-								if cellBufNextLen+1 < columnWidth {
-									cellBuf.WriteString(srcPart)
-									cellBuf.WriteString(WS)
-								} else {
-									cellBuf.WriteString(srcPart)
-								}
-							} else {
-								dstParts = append(dstParts, strings.TrimRight(cellBuf.String(), WS))
-								cellBuf.Reset()
-								cellBuf.WriteString(srcPart)
-								cellBuf.WriteString(WS)
-							}
-						}
-						if j == lastStrPart && cellBuf.Len() > 0 {
-							dstParts = append(dstParts, strings.TrimRight(cellBuf.String(), WS))
-						}
-					}
-					dstPartsLen := len(dstParts)
-					nextHeight := dstPartsLen + style.PaddingTop + style.PaddingBottom
-					if dstPartsLen > 1 {
-						cell.parts = dstParts
-						cell.partsLen = dstPartsLen
-					}
-					if nextHeight > row.height {
-						row.height = nextHeight
-					}
-				} else {
-					nextHeight := style.PaddingTop + style.PaddingBottom + 1
-					if nextHeight > row.height {
-						row.height = nextHeight
-					}
-				}
-			}
+	}
+	for i, rl := range footers {
+		if i == 0 && t.Style.showLine(lineFooter) {
+			t.writeLine(buf, l, spans, lineFooter, prev, rl)
 		}
+		t.writeRow(buf, l, spans, rl)
+		prev = rl
+	}
+	if t.Style.showLine(lineBottom) {
+		t.writeLine(buf, l, spans, lineBottom, prev, nil)
 	}
+	return buf.Flush()
+}
 
-	buf := new(bytes.Buffer)
-	for _, row := range t.rows {
-		t.writeLine(buf, cornerWidth, verticalBorderWidth)
-		for x := 0; x < row.height; x++ {
-			for i, cell := range row.cells {
-				column := t.columns[i]
-				style := column.getStyleByRow(row)
-				buf.WriteString(t.Style.VerticalBorder)
-				columnWidth := column.width - (style.PaddingLeft + style.PaddingRight)
-				if x < style.PaddingTop || x > row.height-style.PaddingBottom {
-					buf.WriteString(t.createEmptyLine(columnWidth))
-				} else {
-					t.writeHorizontalPadding(buf, style.PaddingLeft)
-					if cell.partsLen > 0 {
-						var start int
-						switch style.VerticalAlign {
-						case ColumnVerticalAlignTop:
-							start += style.PaddingTop
-						case ColumnVerticalAlignMiddle:
-							start = (row.height-cell.partsLen)/2 + style.PaddingTop
-						case ColumnVerticalAlignBottom:
-							start = row.height - cell.partsLen
-						}
-						end := cell.partsLen + start
-						if x >= start && x < end {
-							j := x - start
-							t.writeCell(buf, columnWidth, utf8.RuneCountInString(cell.parts[j]), cell.parts[j], style)
-						} else {
-							buf.WriteString(t.createEmptyLine(columnWidth))
-						}
-					} else {
-						var j int
-						switch style.VerticalAlign {
-						case ColumnVerticalAlignTop:
-							j = style.PaddingTop
-						case ColumnVerticalAlignMiddle:
-							j = (row.height-(style.PaddingTop+style.PaddingBottom))/2 + style.PaddingTop
-						case ColumnVerticalAlignBottom:
-							j = row.height - 1 - style.PaddingBottom
-						}
-						if x == j {
-							t.writeCell(buf, columnWidth, cell.width, cell.data, style)
-						} else {
-							buf.WriteString(t.createEmptyLine(columnWidth))
-						}
-					}
-					t.writeHorizontalPadding(buf, style.PaddingRight)
-				}
+func (t *Table) writeRow(buf *tableWriter, l *layout, spans *spanLines, rl *rowLayout) {
+	outerBorder := t.Style.outerBorder()
+	separator, separatorSGR := t.Style.columnSeparator(), l.borderSGR
+	if t.Style.HideVerticalBorders {
+		separatorSGR = ""
+	}
+	for x := 0; x < rl.height; x++ {
+		for i, cell := range rl.cells {
+			if cell.span == 0 {
+				continue
 			}
-			buf.WriteString(t.Style.VerticalBorder)
-			buf.Write(EOL)
+			style := t.columns[i].getStyleByRow(rl.row)
+			if i == 0 {
+				buf.WriteStyled(outerBorder, l.borderSGR)
+			} else {
+				buf.WriteStyled(separator, separatorSGR)
+			}
+			target, drawn, height := spans.resolve(cell, rl.height)
+			t.writeCellLine(buf, l.spanWidth(i, cell.span), style, target, drawn+x, height)
+		}
+		buf.WriteStyled(outerBorder, l.borderSGR)
+		buf.Write(EOL)
+	}
+	for _, cell := range rl.cells {
+		if head, ok := spans.heads[cell]; ok {
+			spans.drawn[head] += rl.height
 		}
 	}
-	t.writeLine(buf, cornerWidth, verticalBorderWidth)
-	return buf.String()
 }
 
-func (t *Table) writeLine(buf *bytes.Buffer, cornerWidth, verticalBorderWidth int) {
-	for _, column := range t.columns {
-		buf.WriteString(t.Style.Corner)
-		buf.WriteString(
-			strings.Repeat(
-				t.Style.HorizontalBorder,
-				verticalBorderWidth+column.width-cornerWidth,
-			),
-		)
+// writeCellLine writes line x of a cell that is height lines tall.
+func (t *Table) writeCellLine(buf *tableWriter, width int, style *ColumnStyle, cell *cellLayout, x, height int) {
+	columnWidth := width - (style.PaddingLeft + style.PaddingRight)
+	if x < style.PaddingTop || x > height-style.PaddingBottom {
+		buf.WriteStyled(t.createEmptyLine(width), cell.background)
+		return
+	}
+	buf.WriteString(cell.background)
+	t.writeHorizontalPadding(buf, style.PaddingLeft)
+	j := x - cell.start(style, height)
+	if j >= 0 && j < len(cell.lines) {
+		t.writeCell(buf, columnWidth, cell.widths[j], cell.styled(j), style)
+	} else {
+		buf.WriteString(t.createEmptyLine(columnWidth))
+	}
+	t.writeHorizontalPadding(buf, style.PaddingRight)
+	if cell.background != "" {
+		buf.WriteString(ansiReset)
+	}
+}
+
+// writeLine draws a horizontal line between the rows above and below,
+// either of which is nil at the edges of the table. Column boundaries
+// inside a column-spanning cell get no arm on that side of the line, and
+// a row-spanning cell crossing the line is written through it.
+func (t *Table) writeLine(buf *tableWriter, l *layout, spans *spanLines, position linePosition, above, below *rowLayout) {
+	horizontal := t.Style.horizontal(position)
+	last := len(l.widths)
+	outerBorderWidth := stringWidth(t.Style.outerBorder())
+	buf.WriteString(l.borderSGR)
+	for i := 0; i < last; {
+		junction, junctionWidth := t.lineJunction(spans, position, above, below, i, last), l.separatorWidth
+		if i == 0 {
+			junctionWidth = outerBorderWidth
+		}
+		buf.WriteString(junction)
+		if !spans.inside(above, below, i) {
+			buf.WriteString(strings.Repeat(horizontal, junctionWidth+l.widths[i]-stringWidth(junction)))
+			i++
+			continue
+		}
+		buf.WriteString(t.createEmptyLine(junctionWidth - stringWidth(junction)))
+		cell := below.cells[i]
+		target, drawn, height := spans.resolve(cell, below.height)
+		if l.borderSGR != "" {
+			buf.WriteString(ansiReset)
+		}
+		style := t.columns[i].getStyleByRow(below.row)
+		t.writeCellLine(buf, l.spanWidth(i, cell.span), style, target, drawn, height)
+		buf.WriteString(l.borderSGR)
+		spans.drawn[target]++
+		i += cell.span
+	}
+	buf.WriteString(t.lineJunction(spans, position, above, below, last, last))
+	if l.borderSGR != "" {
+		buf.WriteString(ansiReset)
 	}
-	buf.WriteString(t.Style.Corner)
 	buf.Write(EOL)
 }
 
-func (t *Table) writeHorizontalPadding(buf *bytes.Buffer, width int) {
+// lineJunction returns the glyph at boundary i of a line, where boundary 0
+// is the left border and boundary last the right one.
+func (t *Table) lineJunction(spans *spanLines, position linePosition, above, below *rowLayout, i, last int) string {
+	s := t.Style
+	if i == 0 || i == last {
+		if s.HideOuterBorder {
+			return ""
+		}
+	} else if s.HideVerticalBorders {
+		return ""
+	}
+	left := i > 0 && !spans.inside(above, below, i-1)
+	right := i < last && !spans.inside(above, below, i)
+	up := above != nil && (i == last || above.cells[i].span != 0)
+	down := below != nil && (i == last || below.cells[i].span != 0)
+	if left == (i > 0) && right == (i < last) && up == (position != lineTop) && down == (position != lineBottom) {
+		first, middle, end := s.junctions(position)
+		switch i {
+		case 0:
+			return first
+		case last:
+			return end
+		}
+		return middle
+	}
+	switch {
+	case up && down && left && right:
+		return s.glyph(s.Cross)
+	case down && left && right:
+		return s.glyph(s.TopJunction)
+	case up && left && right:
+		return s.glyph(s.BottomJunction)
+	case up && down && right:
+		return s.glyph(s.LeftJunction)
+	case up && down && left:
+		return s.glyph(s.RightJunction)
+	case up && down:
+		return s.VerticalBorder
+	case down && right:
+		return s.glyph(s.TopLeftCorner)
+	case down && left:
+		return s.glyph(s.TopRightCorner)
+	case up && right:
+		return s.glyph(s.BottomLeftCorner)
+	case up && left:
+		return s.glyph(s.BottomRightCorner)
+	}
+	return ""
+}
+
+func (t *Table) writeHorizontalPadding(buf *tableWriter, width int) {
 	buf.WriteString(t.createEmptyLine(width))
 }
 
-func (t *Table) writeCell(buf *bytes.Buffer, columnWidth, cellWidth int, data string, style *ColumnStyle) {
+func (t *Table) writeCell(buf *tableWriter, columnWidth, cellWidth int, data string, style *ColumnStyle) {
 	isWriteWhiteSpace := columnWidth > cellWidth
 	diff := columnWidth - cellWidth
 	switch style.Align {
@@ -275,7 +413,7 @@ func (t *Table) writeCell(buf *bytes.Buffer, columnWidth, cellWidth int, data st
 		if isWriteWhiteSpace {
 			buf.WriteString(strings.Repeat(WS, diff-side))
 		}
-	case ColumnAlignRight:
+	case ColumnAlignRight, ColumnAlignDecimal:
 		if isWriteWhiteSpace {
 			buf.WriteString(strings.Repeat(WS, diff))
 		}
@@ -305,4 +443,5 @@ func (t *Table) GetColumnByName(name string) *Column {
 
 func (t *Table) Clean() {
 	t.rows = append([]*Row{}, t.rows[0])
+	t.rowSpans = nil
 }
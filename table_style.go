@@ -0,0 +1,274 @@
+package clitable
+
+import "strings"
+
+type TableStyle struct {
+	VerticalBorder    string
+	HorizontalBorder  string
+	Corner            string
+	TopLeftCorner     string
+	TopRightCorner    string
+	BottomLeftCorner  string
+	BottomRightCorner string
+	TopJunction       string
+	BottomJunction    string
+	LeftJunction      string
+	RightJunction     string
+	Cross             string
+	// HeaderBorder and the Header* junctions draw the line under the
+	// header. Empty values fall back to the regular separator glyphs.
+	HeaderBorder        string
+	HeaderLeftJunction  string
+	HeaderRightJunction string
+	HeaderCross         string
+	HideHeaderSeparator bool
+	// FooterBorder draws the line above the footer rows, HorizontalBorder
+	// if empty.
+	FooterBorder        string
+	HideFooterSeparator bool
+	// RowSeparators controls the lines between body rows: RowSeparatorsAll
+	// draws one before every row, RowSeparatorsNone draws none and a
+	// positive N draws one after every N rows.
+	RowSeparators int
+	// HideOuterBorder drops the frame around the table,
+	// HideVerticalBorders replaces the lines between columns with
+	// ColumnGap spaces and HideHorizontalBorders drops every horizontal
+	// line.
+	HideOuterBorder       bool
+	HideVerticalBorders   bool
+	HideHorizontalBorders bool
+	// HideTopBorder and HideBottomBorder drop the line above and below
+	// the table and keep the rest of the frame.
+	HideTopBorder    bool
+	HideBottomBorder bool
+	ColumnGap        int
+	BorderStyle      *TextStyle
+}
+
+const (
+	RowSeparatorsAll  = 0
+	RowSeparatorsNone = -1
+)
+
+type linePosition int
+
+const (
+	lineTop linePosition = iota
+	lineHeader
+	lineMiddle
+	lineFooter
+	lineBottom
+)
+
+func orDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+func (s *TableStyle) glyph(glyph string) string {
+	return orDefault(glyph, s.Corner)
+}
+
+func (s *TableStyle) junctions(position linePosition) (string, string, string) {
+	switch position {
+	case lineTop:
+		return s.glyph(s.TopLeftCorner), s.glyph(s.TopJunction), s.glyph(s.TopRightCorner)
+	case lineBottom:
+		return s.glyph(s.BottomLeftCorner), s.glyph(s.BottomJunction), s.glyph(s.BottomRightCorner)
+	}
+	left, middle, right := s.glyph(s.LeftJunction), s.glyph(s.Cross), s.glyph(s.RightJunction)
+	if position == lineHeader {
+		left = orDefault(s.HeaderLeftJunction, left)
+		middle = orDefault(s.HeaderCross, middle)
+		right = orDefault(s.HeaderRightJunction, right)
+	}
+	return left, middle, right
+}
+
+func (s *TableStyle) horizontal(position linePosition) string {
+	switch position {
+	case lineHeader:
+		return orDefault(s.HeaderBorder, s.HorizontalBorder)
+	case lineFooter:
+		return orDefault(s.FooterBorder, s.HorizontalBorder)
+	}
+	return s.HorizontalBorder
+}
+
+func (s *TableStyle) outerBorder() string {
+	if s.HideOuterBorder {
+		return ""
+	}
+	return s.VerticalBorder
+}
+
+func (s *TableStyle) columnSeparator() string {
+	if s.HideVerticalBorders {
+		return strings.Repeat(WS, s.ColumnGap)
+	}
+	return s.VerticalBorder
+}
+
+func (s *TableStyle) chromeWidth(columns int) int {
+	width := 2 * stringWidth(s.outerBorder())
+	if columns > 1 {
+		width += (columns - 1) * stringWidth(s.columnSeparator())
+	}
+	return width
+}
+
+func (s *TableStyle) showLine(position linePosition) bool {
+	switch {
+	case s.HideHorizontalBorders:
+		return false
+	case position == lineTop:
+		return !s.HideOuterBorder && !s.HideTopBorder
+	case position == lineBottom:
+		return !s.HideOuterBorder && !s.HideBottomBorder
+	case position == lineFooter:
+		return !s.HideFooterSeparator
+	}
+	return true
+}
+
+func (s *TableStyle) separatorBefore(prev, row *Row, bodyIndex int) (linePosition, bool) {
+	position, ok := s.rowSeparator(prev, row, bodyIndex)
+	return position, ok && s.showLine(position)
+}
+
+func (s *TableStyle) rowSeparator(prev, row *Row, bodyIndex int) (linePosition, bool) {
+	switch {
+	case prev == nil:
+		return lineTop, true
+	case prev.isHeader:
+		return lineHeader, !s.HideHeaderSeparator
+	case row.isHeader || row.sectionBreak:
+		return lineMiddle, true
+	case s.RowSeparators == RowSeparatorsAll:
+		return lineMiddle, true
+	case s.RowSeparators > 0:
+		return lineMiddle, bodyIndex%s.RowSeparators == 0
+	}
+	return lineMiddle, false
+}
+
+// The TableStyle presets return a new style on every call, so changing
+// the style of one table does not change the others.
+
+func TableStyleASCII() *TableStyle {
+	return &TableStyle{
+		VerticalBorder:   "|",
+		HorizontalBorder: "-",
+		Corner:           "+",
+	}
+}
+
+func TableStyleSingle() *TableStyle {
+	return &TableStyle{
+		VerticalBorder:    "│",
+		HorizontalBorder:  "─",
+		Corner:            "┼",
+		TopLeftCorner:     "┌",
+		TopRightCorner:    "┐",
+		BottomLeftCorner:  "└",
+		BottomRightCorner: "┘",
+		TopJunction:       "┬",
+		BottomJunction:    "┴",
+		LeftJunction:      "├",
+		RightJunction:     "┤",
+		Cross:             "┼",
+	}
+}
+
+func TableStyleDouble() *TableStyle {
+	return &TableStyle{
+		VerticalBorder:    "║",
+		HorizontalBorder:  "═",
+		Corner:            "╬",
+		TopLeftCorner:     "╔",
+		TopRightCorner:    "╗",
+		BottomLeftCorner:  "╚",
+		BottomRightCorner: "╝",
+		TopJunction:       "╦",
+		BottomJunction:    "╩",
+		LeftJunction:      "╠",
+		RightJunction:     "╣",
+		Cross:             "╬",
+	}
+}
+
+func TableStyleRounded() *TableStyle {
+	return &TableStyle{
+		VerticalBorder:    "│",
+		HorizontalBorder:  "─",
+		Corner:            "┼",
+		TopLeftCorner:     "╭",
+		TopRightCorner:    "╮",
+		BottomLeftCorner:  "╰",
+		BottomRightCorner: "╯",
+		TopJunction:       "┬",
+		BottomJunction:    "┴",
+		LeftJunction:      "├",
+		RightJunction:     "┤",
+		Cross:             "┼",
+	}
+}
+
+func TableStyleHeavy() *TableStyle {
+	return &TableStyle{
+		VerticalBorder:    "┃",
+		HorizontalBorder:  "━",
+		Corner:            "╋",
+		TopLeftCorner:     "┏",
+		TopRightCorner:    "┓",
+		BottomLeftCorner:  "┗",
+		BottomRightCorner: "┛",
+		TopJunction:       "┳",
+		BottomJunction:    "┻",
+		LeftJunction:      "┣",
+		RightJunction:     "┫",
+		Cross:             "╋",
+	}
+}
+
+func TableStyleDashed() *TableStyle {
+	return &TableStyle{
+		VerticalBorder:    "┆",
+		HorizontalBorder:  "┄",
+		Corner:            "┼",
+		TopLeftCorner:     "┌",
+		TopRightCorner:    "┐",
+		BottomLeftCorner:  "└",
+		BottomRightCorner: "┘",
+		TopJunction:       "┬",
+		BottomJunction:    "┴",
+		LeftJunction:      "├",
+		RightJunction:     "┤",
+		Cross:             "┼",
+	}
+}
+
+func TableStyleBorderless() *TableStyle {
+	return &TableStyle{
+		VerticalBorder:        "|",
+		HorizontalBorder:      "-",
+		Corner:                "+",
+		HideOuterBorder:       true,
+		HideVerticalBorders:   true,
+		HideHorizontalBorders: true,
+		ColumnGap:             3,
+	}
+}
+
+func TableStyleMarkdown() *TableStyle {
+	return &TableStyle{
+		VerticalBorder:   "|",
+		HorizontalBorder: "-",
+		Corner:           "|",
+		RowSeparators:    RowSeparatorsNone,
+		HideTopBorder:    true,
+		HideBottomBorder: true,
+	}
+}
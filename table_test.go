@@ -1,6 +1,10 @@
 package clitable
 
-import "testing"
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
 
 func TestSimpleHeader(t *testing.T) {
 	table := NewTable("id")
@@ -323,3 +327,217 @@ func TestCustomBorderAndCorner(t *testing.T) {
 		t.Fail()
 	}
 }
+
+type failingWriter struct{}
+
+func (w failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestWriteTo(t *testing.T) {
+	table := NewTable("id", "name")
+	table.AddRow(1, "first")
+
+	buf := new(bytes.Buffer)
+	n, err := table.WriteTo(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(buf.Len()) || buf.String() != table.String() {
+		t.Fail()
+	}
+
+	if _, err := table.WriteTo(failingWriter{}); err == nil {
+		t.Fail()
+	}
+}
+
+func TestRepeatedRender(t *testing.T) {
+	WinSize.Col = 24
+	defer func() { WinSize.Col = 0 }()
+	table := NewTable("id", "too long header super name")
+	first := table.String()
+	if table.String() != first {
+		t.Fail()
+	}
+
+	table.AddRow(1, "a")
+	expected := NewTable("id", "too long header super name")
+	expected.AddRow(1, "a")
+	tableStr := table.String()
+	t.Log(tableStr)
+	if tableStr != expected.String() {
+		t.Fail()
+	}
+}
+
+func TestStylePreset(t *testing.T) {
+	table := NewTable("id")
+	table.Style = TableStyleDouble()
+
+	header :=
+		"╔══╗\n" +
+			"║id║\n" +
+			"╚══╝\n"
+
+	tableStr := table.String()
+	t.Log(tableStr)
+	t.Log(header)
+	if tableStr != header {
+		t.Fail()
+	}
+}
+
+func TestJunctions(t *testing.T) {
+	table := NewTable("id", "name")
+	table.Style = TableStyleRounded()
+	table.AddRow(1, "first")
+
+	header :=
+		"╭──┬─────╮\n" +
+			"│id│name │\n" +
+			"├──┼─────┤\n" +
+			"│1 │first│\n" +
+			"╰──┴─────╯\n"
+
+	tableStr := table.String()
+	t.Log(tableStr)
+	t.Log(header)
+	if tableStr != header {
+		t.Fail()
+	}
+}
+
+func TestSeparators(t *testing.T) {
+	table := NewTable("id", "name")
+	table.Style = &TableStyle{
+		VerticalBorder:   "|",
+		HorizontalBorder: "-",
+		Corner:           "+",
+		HeaderBorder:     "=",
+		RowSeparators:    2,
+	}
+	table.AddRow(1, "a")
+	table.AddRow(2, "b")
+	table.AddRow(3, "c")
+	table.AddSeparator()
+	table.AddRow(4, "d")
+
+	header :=
+		"+--+----+\n" +
+			"|id|name|\n" +
+			"+==+====+\n" +
+			"|1 |a   |\n" +
+			"|2 |b   |\n" +
+			"+--+----+\n" +
+			"|3 |c   |\n" +
+			"+--+----+\n" +
+			"|4 |d   |\n" +
+			"+--+----+\n"
+
+	tableStr := table.String()
+	t.Log(tableStr)
+	t.Log(header)
+	if tableStr != header {
+		t.Fail()
+	}
+
+	table.Style.RowSeparators = RowSeparatorsNone
+	table.Style.HideHeaderSeparator = true
+	header =
+		"+--+----+\n" +
+			"|id|name|\n" +
+			"|1 |a   |\n" +
+			"|2 |b   |\n" +
+			"|3 |c   |\n" +
+			"+--+----+\n" +
+			"|4 |d   |\n" +
+			"+--+----+\n"
+
+	tableStr = table.String()
+	t.Log(tableStr)
+	t.Log(header)
+	if tableStr != header {
+		t.Fail()
+	}
+}
+
+func TestBorderless(t *testing.T) {
+	table := NewTable("NAME", "STATUS")
+	table.Style = TableStyleBorderless()
+	for _, column := range []string{"NAME", "STATUS"} {
+		table.GetColumnByName(column).HeaderStyle = &ColumnStyle{}
+	}
+	table.AddRow("web-1", "Running")
+	table.AddRow("db", "Pending")
+
+	header :=
+		"NAME    STATUS \n" +
+			"web-1   Running\n" +
+			"db      Pending\n"
+
+	tableStr := table.String()
+	t.Log(tableStr)
+	t.Log(header)
+	if tableStr != header {
+		t.Fail()
+	}
+}
+
+func TestInnerBordersOnly(t *testing.T) {
+	table := NewTable("id", "name")
+	table.Style = &TableStyle{
+		VerticalBorder:   "|",
+		HorizontalBorder: "-",
+		Corner:           "+",
+		HideOuterBorder:  true,
+	}
+	table.AddRow(1, "first")
+
+	header :=
+		"id|name \n" +
+			"--+-----\n" +
+			"1 |first\n"
+
+	tableStr := table.String()
+	t.Log(tableStr)
+	t.Log(header)
+	if tableStr != header {
+		t.Fail()
+	}
+}
+
+func TestMarkdownStylePreset(t *testing.T) {
+	table := NewTable("id", "name")
+	table.Style = TableStyleMarkdown()
+	table.AddRow(1, "first")
+	table.AddRow(2, "second")
+
+	header :=
+		"|id| name |\n" +
+			"|--|------|\n" +
+			"|1 |first |\n" +
+			"|2 |second|\n"
+
+	tableStr := table.String()
+	t.Log(tableStr)
+	t.Log(header)
+	if tableStr != header {
+		t.Fail()
+	}
+}
+
+func TestStylePresetCopy(t *testing.T) {
+	first, second := NewTable("id"), NewTable("id")
+	first.Style = TableStyleSingle()
+	second.Style = TableStyleSingle()
+	first.Style.RowSeparators = RowSeparatorsNone
+	first.Style.HideOuterBorder = true
+	if second.Style.RowSeparators != RowSeparatorsAll || second.Style.HideOuterBorder {
+		t.Fail()
+	}
+	NewTable("id").Style.HideOuterBorder = true
+	if NewTable("id").Style.HideOuterBorder {
+		t.Fail()
+	}
+}
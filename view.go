@@ -0,0 +1,121 @@
+package clitable
+
+// TableView is a read-only view of a table for renderers.
+type TableView struct {
+	table *Table
+}
+
+type ColumnView struct {
+	column *Column
+}
+
+type RowView struct {
+	table *Table
+	row   *Row
+}
+
+func (t *Table) View() *TableView {
+	return &TableView{table: t}
+}
+
+func (v *TableView) Caption() string {
+	return v.table.Caption
+}
+
+func (v *TableView) Style() TableStyle {
+	return *v.table.Style
+}
+
+func (v *TableView) ColumnsLen() int {
+	return len(v.table.columns)
+}
+
+func (v *TableView) Column(i int) ColumnView {
+	return ColumnView{column: v.table.columns[i]}
+}
+
+func (v *TableView) Header() RowView {
+	return RowView{table: v.table, row: v.table.rows[0]}
+}
+
+func (v *TableView) Rows() []RowView {
+	rows := make([]RowView, 0, len(v.table.rows))
+	for _, row := range v.table.rows {
+		if !row.isHeader {
+			rows = append(rows, RowView{table: v.table, row: row})
+		}
+	}
+	return rows
+}
+
+func (v *TableView) Footers() []RowView {
+	rows := make([]RowView, len(v.table.footers))
+	for i, row := range v.table.footers {
+		rows[i] = RowView{table: v.table, row: row}
+	}
+	return rows
+}
+
+func (c ColumnView) Name() string {
+	return c.column.name
+}
+
+func (c ColumnView) HeaderStyle() ColumnStyle {
+	return *c.column.HeaderStyle
+}
+
+func (c ColumnView) BodyStyle() ColumnStyle {
+	return *c.column.BodyStyle
+}
+
+func (c ColumnView) FooterStyle() ColumnStyle {
+	return *c.column.FooterStyle
+}
+
+func (r RowView) IsHeader() bool {
+	return r.row.isHeader
+}
+
+func (r RowView) IsFooter() bool {
+	return r.row.isFooter
+}
+
+func (r RowView) Len() int {
+	return len(r.row.cells)
+}
+
+// Value returns the value the cell was created from, the value of the row
+// span covering it, or the computed value of a footer aggregate.
+func (r RowView) Value(i int) interface{} {
+	return r.table.getCellValue(r.row, i)
+}
+
+// String returns the cell text as the text renderer prints it.
+func (r RowView) String(i int) string {
+	return r.table.getCellData(r.row, i)
+}
+
+// TextStyle returns the style of the cell merged with its column and row
+// styles.
+func (r RowView) TextStyle(i int) TextStyle {
+	style := r.table.columns[i].getStyleByRow(r.row)
+	return style.TextStyle.merge(r.row.Style).merge(r.row.cells[i].spanned().Style)
+}
+
+// ValueByName returns the value of the cell in the named column.
+func (r RowView) ValueByName(name string) (interface{}, bool) {
+	i, ok := r.table.getColumnIndex(name)
+	if !ok {
+		return nil, false
+	}
+	return r.Value(i), true
+}
+
+// StringByName returns the text of the cell in the named column.
+func (r RowView) StringByName(name string) (string, bool) {
+	i, ok := r.table.getColumnIndex(name)
+	if !ok {
+		return "", false
+	}
+	return r.String(i), true
+}
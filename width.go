@@ -0,0 +1,132 @@
+package clitable
+
+import (
+	"sort"
+	"unicode"
+	"unicode/utf8"
+)
+
+type runeRange struct {
+	lo, hi rune
+}
+
+// wideRanges lists the East Asian Wide (W) and Fullwidth (F) code points,
+// including emoji with default emoji presentation.
+var wideRanges = []runeRange{
+	{0x1100, 0x115F}, {0x231A, 0x231B}, {0x2329, 0x232A}, {0x23E9, 0x23EC},
+	{0x23F0, 0x23F0}, {0x23F3, 0x23F3}, {0x25FD, 0x25FE}, {0x2614, 0x2615},
+	{0x2648, 0x2653}, {0x267F, 0x267F}, {0x2693, 0x2693}, {0x26A1, 0x26A1},
+	{0x26AA, 0x26AB}, {0x26BD, 0x26BE}, {0x26C4, 0x26C5}, {0x26CE, 0x26CE},
+	{0x26D4, 0x26D4}, {0x26EA, 0x26EA}, {0x26F2, 0x26F3}, {0x26F5, 0x26F5},
+	{0x26FA, 0x26FA}, {0x26FD, 0x26FD}, {0x2705, 0x2705}, {0x270A, 0x270B},
+	{0x2728, 0x2728}, {0x274C, 0x274C}, {0x274E, 0x274E}, {0x2753, 0x2755},
+	{0x2757, 0x2757}, {0x2795, 0x2797}, {0x27B0, 0x27B0}, {0x27BF, 0x27BF},
+	{0x2B1B, 0x2B1C}, {0x2B50, 0x2B50}, {0x2B55, 0x2B55}, {0x2E80, 0x303E},
+	{0x3041, 0x33FF}, {0x3400, 0x4DBF}, {0x4E00, 0x9FFF}, {0xA000, 0xA4CF},
+	{0xA960, 0xA97F}, {0xAC00, 0xD7A3}, {0xF900, 0xFAFF}, {0xFE10, 0xFE19},
+	{0xFE30, 0xFE6F}, {0xFF00, 0xFF60}, {0xFFE0, 0xFFE6}, {0x16FE0, 0x16FE4},
+	{0x17000, 0x18AFF}, {0x1B000, 0x1B2FF}, {0x1F004, 0x1F004}, {0x1F0CF, 0x1F0CF},
+	{0x1F18E, 0x1F18E}, {0x1F191, 0x1F19A}, {0x1F200, 0x1F202}, {0x1F210, 0x1F23B},
+	{0x1F240, 0x1F248}, {0x1F250, 0x1F251}, {0x1F260, 0x1F265}, {0x1F300, 0x1F320},
+	{0x1F32D, 0x1F335}, {0x1F337, 0x1F37C}, {0x1F37E, 0x1F393}, {0x1F3A0, 0x1F3CA},
+	{0x1F3CF, 0x1F3D3}, {0x1F3E0, 0x1F3F0}, {0x1F3F4, 0x1F3F4}, {0x1F3F8, 0x1F43E},
+	{0x1F440, 0x1F440}, {0x1F442, 0x1F4FC}, {0x1F4FF, 0x1F53D}, {0x1F54B, 0x1F54E},
+	{0x1F550, 0x1F567}, {0x1F57A, 0x1F57A}, {0x1F595, 0x1F596}, {0x1F5A4, 0x1F5A4},
+	{0x1F5FB, 0x1F64F}, {0x1F680, 0x1F6C5}, {0x1F6CC, 0x1F6CC}, {0x1F6D0, 0x1F6D2},
+	{0x1F6D5, 0x1F6D7}, {0x1F6DC, 0x1F6DF}, {0x1F6EB, 0x1F6EC}, {0x1F6F4, 0x1F6FC},
+	{0x1F7E0, 0x1F7EB}, {0x1F7F0, 0x1F7F0}, {0x1F90C, 0x1F93A}, {0x1F93C, 0x1F945},
+	{0x1F947, 0x1F9FF}, {0x1FA70, 0x1FAFF}, {0x20000, 0x2FFFD}, {0x30000, 0x3FFFD},
+}
+
+func inRanges(r rune, ranges []runeRange) bool {
+	i := sort.Search(len(ranges), func(i int) bool {
+		return ranges[i].hi >= r
+	})
+	return i < len(ranges) && ranges[i].lo <= r
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+func isHangulJamoFollower(r rune) bool {
+	return (r >= 0x1160 && r <= 0x11FF) || (r >= 0xD7B0 && r <= 0xD7FF)
+}
+
+func isZeroWidth(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc, unicode.Cf) ||
+		isHangulJamoFollower(r)
+}
+
+func runeWidth(r rune) int {
+	switch {
+	case r == 0 || unicode.IsControl(r) || isZeroWidth(r):
+		return 0
+	case isRegionalIndicator(r) || inRanges(r, wideRanges):
+		return 2
+	default:
+		return 1
+	}
+}
+
+// extendsGrapheme reports whether r continues the grapheme cluster whose
+// previous rune is prev. regionalIndicators is the number of regional
+// indicators already in the cluster.
+func extendsGrapheme(prev, r rune, regionalIndicators int) bool {
+	switch {
+	case unicode.IsControl(r) || unicode.IsControl(prev):
+		return false
+	case prev == '\u200d' || r == '\u200d':
+		return true
+	case r >= 0x1F3FB && r <= 0x1F3FF:
+		return true
+	case isRegionalIndicator(r):
+		return isRegionalIndicator(prev) && regionalIndicators%2 == 1
+	}
+	return isZeroWidth(r)
+}
+
+// eachGrapheme calls fn for every grapheme cluster in s together with the
+// number of terminal cells it occupies. Escape sequences are passed to fn
+// on their own with zero width.
+func eachGrapheme(s string, fn func(grapheme string, width int)) {
+	start, width, regionalIndicators := 0, 0, 0
+	prev := rune(-1)
+	for i := 0; i < len(s); {
+		if n := ansiSequenceLen(s[i:]); n > 0 {
+			if i > start {
+				fn(s[start:i], width)
+			}
+			fn(s[i:i+n], 0)
+			i += n
+			start, width, regionalIndicators, prev = i, 0, 0, -1
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if i > start && !extendsGrapheme(prev, r, regionalIndicators) {
+			fn(s[start:i], width)
+			start, width, regionalIndicators = i, 0, 0
+		}
+		if i == start {
+			width = runeWidth(r)
+		} else if r == '\ufe0f' && width == 1 {
+			width = 2
+		}
+		if isRegionalIndicator(r) {
+			regionalIndicators++
+		}
+		prev = r
+		i += size
+	}
+	if start < len(s) {
+		fn(s[start:], width)
+	}
+}
+
+func stringWidth(s string) int {
+	width := 0
+	eachGrapheme(s, func(_ string, w int) {
+		width += w
+	})
+	return width
+}
@@ -0,0 +1,58 @@
+package clitable
+
+import "testing"
+
+func TestStringWidth(t *testing.T) {
+	cases := map[string]int{
+		"id":                         2,
+		"Имя":                        3,
+		"日本語":                        6,
+		"ｆｕｌｌ":                       8,
+		"e\u0301":                    1,
+		"\U0001F600":                 2,
+		"\U0001F468\u200d\U0001F469": 2,
+		"\U0001F1EF\U0001F1F5":       2,
+		"\u2764\ufe0f":               2,
+		"a\u200bb":                   2,
+	}
+	for s, expected := range cases {
+		if width := stringWidth(s); width != expected {
+			t.Errorf("stringWidth(%q) = %d, expected %d", s, width, expected)
+		}
+	}
+}
+
+func TestWideCharacters(t *testing.T) {
+	table := NewTable("id", "名前")
+	table.AddRow(1, "東京")
+	table.AddRow(2, "Osaka")
+
+	header :=
+		"+--+-----+\n" +
+			"|id|名前 |\n" +
+			"+--+-----+\n" +
+			"|1 |東京 |\n" +
+			"+--+-----+\n" +
+			"|2 |Osaka|\n" +
+			"+--+-----+\n"
+
+	tableStr := table.String()
+	t.Log(tableStr)
+	t.Log(header)
+	if tableStr != header {
+		t.Fail()
+	}
+}
+
+func TestWrapWideCharacters(t *testing.T) {
+	parts := wrap("日本語のテキスト", 5)
+	expected := []string{"日本", "語の", "テキ", "スト"}
+	if len(parts) != len(expected) {
+		t.Fatal(parts)
+	}
+	for i := range parts {
+		if parts[i] != expected[i] {
+			t.Fatal(parts)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package clitable
+
+import (
+	"bufio"
+	"io"
+)
+
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+type tableWriter struct {
+	counter *countWriter
+	buf     *bufio.Writer
+	err     error
+}
+
+func newTableWriter(w io.Writer) *tableWriter {
+	counter := &countWriter{w: w}
+	return &tableWriter{
+		counter: counter,
+		buf:     bufio.NewWriter(counter),
+	}
+}
+
+func (w *tableWriter) WriteString(s string) {
+	if w.err == nil {
+		_, w.err = w.buf.WriteString(s)
+	}
+}
+
+func (w *tableWriter) WriteStyled(s, sgr string) {
+	if s == "" {
+		return
+	}
+	if sgr == "" {
+		w.WriteString(s)
+		return
+	}
+	w.WriteString(sgr)
+	w.WriteString(s)
+	w.WriteString(ansiReset)
+}
+
+func (w *tableWriter) Write(p []byte) {
+	if w.err == nil {
+		_, w.err = w.buf.Write(p)
+	}
+}
+
+func (w *tableWriter) Flush() error {
+	if w.err == nil {
+		w.err = w.buf.Flush()
+	}
+	return w.err
+}
+
+func (w *tableWriter) Count() int64 {
+	return w.counter.n
+}